@@ -0,0 +1,83 @@
+// arquetipos.go - Tipos de inimigo e os atributos de cada um
+package main
+
+import "time"
+
+// ArquetipoInimigo descreve um tipo de inimigo
+// Os atributos são lidos pela goroutine do inimigo (alcance, dano, fuga),
+// pela simulação (intervalo entre as ações) e pelas explosões (vida)
+type ArquetipoInimigo struct {
+	Tipo      string   // tipo do elemento na legenda do mapa
+	Rotulo    string   // nome exibido nos logs
+	Sprite    Elemento // símbolo e cor no mapa
+	Intervalo float64  // multiplica o intervalo entre ações do mapa (menor é mais rápido)
+	Alcance   int      // distância máxima (Manhattan) em que enxerga o personagem
+	Dano      int      // corações tirados a cada toque no personagem
+	Vida      int      // explosões necessárias para eliminá-lo
+	Atravessa bool     // anda através das paredes
+	Foge      bool     // foge do personagem em vez de persegui-lo
+	Atira     bool     // dispara projéteis quando alinhado com o personagem
+	Tamanho   int      // lado do quadrado de células ocupado (zero: uma célula)
+	Chefe     bool     // ataca em fases conforme perde vida (veja chefeAtacar)
+}
+
+// Sprites dos tipos de inimigo além do comum (Inimigo)
+var (
+	Batedor  = Elemento{'♞', CorAmarelo, CorPadrao, true}
+	Tanque   = Elemento{'♜', CorMagenta, CorPadrao, true}
+	Fantasma = Elemento{'☁', CorCiano, CorPadrao, true}
+	Covarde  = Elemento{'☹', CorAzul, CorPadrao, true}
+	Atirador = Elemento{'Ψ', CorBranco, CorPadrao, true}
+)
+
+// Tipos de inimigo, na ordem em que aparecem na legenda padrão
+var arquetiposInimigo = []ArquetipoInimigo{
+	{Tipo: TipoInimigo, Rotulo: "Inimigo", Sprite: Inimigo, Intervalo: 1, Alcance: AlcanceDeteccao, Dano: 1, Vida: 1},
+	{Tipo: TipoBatedor, Rotulo: "Batedor", Sprite: Batedor, Intervalo: 0.5, Alcance: 14, Dano: 1, Vida: 1},
+	{Tipo: TipoTanque, Rotulo: "Tanque", Sprite: Tanque, Intervalo: 2, Alcance: 8, Dano: 2, Vida: 3},
+	{Tipo: TipoFantasma, Rotulo: "Fantasma", Sprite: Fantasma, Intervalo: 1.5, Alcance: 8, Dano: 1, Vida: 1, Atravessa: true},
+	{Tipo: TipoCovarde, Rotulo: "Covarde", Sprite: Covarde, Intervalo: 0.8, Alcance: 8, Dano: 1, Vida: 1, Foge: true},
+	{Tipo: TipoAtirador, Rotulo: "Atirador", Sprite: Atirador, Intervalo: 1.2, Alcance: 12, Dano: 1, Vida: 1, Atira: true},
+	{Tipo: TipoChefe, Rotulo: "Chefe", Sprite: Chefe, Intervalo: 1.5, Alcance: 14, Dano: 2, Vida: 12, Tamanho: 2, Chefe: true},
+}
+
+// ============================================================================
+// MÓDULO DE CONSULTA DOS TIPOS
+// ============================================================================
+
+// Procura o tipo de inimigo pelo nome usado na legenda
+func arquetipoBuscar(tipo string) (ArquetipoInimigo, bool) {
+	for _, a := range arquetiposInimigo {
+		if a.Tipo == tipo {
+			return a, true
+		}
+	}
+	return ArquetipoInimigo{}, false
+}
+
+// Retorna o tipo do inimigo
+// Entidades sem tipo (como as de salvamentos antigos) são inimigos comuns
+func arquetipoDe(ent Entidade) ArquetipoInimigo {
+	if a, ok := arquetipoBuscar(ent.Tipo); ok {
+		return a
+	}
+	return arquetiposInimigo[0]
+}
+
+// Indica se o tipo da legenda é algum tipo de inimigo
+func arquetipoEhInimigo(tipo string) bool {
+	_, ok := arquetipoBuscar(tipo)
+	return ok
+}
+
+// Retorna o lado do quadrado de células ocupado pela entidade
+// A posição (X, Y) é o canto superior esquerdo do quadrado
+func arquetipoTamanho(ent Entidade) int {
+	return max(1, arquetipoDe(ent).Tamanho)
+}
+
+// Calcula o intervalo entre duas ações do inimigo
+// É o intervalo definido pelo mapa ajustado pela velocidade do tipo
+func arquetipoIntervalo(jogo *Jogo, ent Entidade) time.Duration {
+	return time.Duration(float64(jogo.Cabecalho.IntervaloInimigo) * arquetipoDe(ent).Intervalo)
+}
@@ -0,0 +1,149 @@
+// caminho.go - Busca de caminhos no mapa (A*) usada pelos inimigos
+package main
+
+import "container/heap"
+
+// Ponto é uma célula do mapa
+type Ponto struct {
+	X, Y int
+}
+
+// CaminhoInimigo guarda o último caminho calculado por um inimigo
+// O caminho só é recalculado quando o personagem muda de célula, quando o
+// inimigo sai do caminho (por exemplo, bloqueado por outro inimigo) ou
+// quando o mapa muda (uma parede frágil derrubada pode abrir um atalho)
+type CaminhoInimigo struct {
+	Alvo   Ponto   // célula do personagem quando o caminho foi calculado
+	Passos []Ponto // células do caminho, da origem até o alvo
+	Versao int     // versão do mapa em que o caminho foi calculado
+}
+
+// Deslocamentos das quatro direções (sem diagonais), em ordem fixa para que
+// empates sejam resolvidos sempre da mesma forma
+var direcoesCaminho = [4]Ponto{{0, -1}, {-1, 0}, {0, 1}, {1, 0}}
+
+// ============================================================================
+// MÓDULO DE BUSCA A*
+// ============================================================================
+
+// Calcula o menor caminho do inimigo até o destino com A*
+// As células válidas são as aceitas por jogoPodeMoverParaInimigo e a
+// heurística é a distância Manhattan. Retorna as células da origem até o
+// destino (inclusive) ou nil se não houver caminho
+func caminhoAEstrela(jogo *Jogo, id int, origem, destino Ponto) []Ponto {
+	abertos := &filaCaminho{}
+	heap.Push(abertos, noCaminho{ponto: origem, estimado: caminhoHeuristica(origem, destino)})
+
+	custo := map[Ponto]int{origem: 0}
+	anterior := make(map[Ponto]Ponto)
+	ordem := 1 // desempate estável entre nós com a mesma estimativa
+
+	for abertos.Len() > 0 {
+		atual := heap.Pop(abertos).(noCaminho)
+		if atual.ponto == destino {
+			return caminhoReconstruir(anterior, origem, destino)
+		}
+		if atual.custo > custo[atual.ponto] {
+			continue // Entrada antiga: a célula já foi alcançada por um caminho menor
+		}
+
+		for _, d := range direcoesCaminho {
+			vizinho := Ponto{atual.ponto.X + d.X, atual.ponto.Y + d.Y}
+			if !jogoPodeMoverParaInimigo(jogo, vizinho.X, vizinho.Y, id) {
+				continue
+			}
+
+			novoCusto := atual.custo + 1
+			if c, visto := custo[vizinho]; visto && c <= novoCusto {
+				continue
+			}
+			custo[vizinho] = novoCusto
+			anterior[vizinho] = atual.ponto
+			heap.Push(abertos, noCaminho{
+				ponto:    vizinho,
+				custo:    novoCusto,
+				estimado: novoCusto + caminhoHeuristica(vizinho, destino),
+				ordem:    ordem,
+			})
+			ordem++
+		}
+	}
+	return nil
+}
+
+// Distância Manhattan entre duas células
+func caminhoHeuristica(a, b Ponto) int {
+	return abs(a.X-b.X) + abs(a.Y-b.Y)
+}
+
+// Monta o caminho percorrendo os antecessores do destino até a origem
+func caminhoReconstruir(anterior map[Ponto]Ponto, origem, destino Ponto) []Ponto {
+	var caminho []Ponto
+	for p := destino; p != origem; p = anterior[p] {
+		caminho = append(caminho, p)
+	}
+	caminho = append(caminho, origem)
+
+	// Inverte para ficar da origem ao destino
+	for i, j := 0, len(caminho)-1; i < j; i, j = i+1, j-1 {
+		caminho[i], caminho[j] = caminho[j], caminho[i]
+	}
+	return caminho
+}
+
+// Nó da fila de prioridade do A*
+type noCaminho struct {
+	ponto    Ponto
+	custo    int // passos desde a origem
+	estimado int // custo + heurística até o destino
+	ordem    int
+}
+
+// filaCaminho é a fila de prioridade (heap mínimo) dos nós abertos
+type filaCaminho []noCaminho
+
+func (f filaCaminho) Len() int { return len(f) }
+func (f filaCaminho) Less(i, j int) bool {
+	if f[i].estimado != f[j].estimado {
+		return f[i].estimado < f[j].estimado
+	}
+	return f[i].ordem < f[j].ordem
+}
+func (f filaCaminho) Swap(i, j int) { f[i], f[j] = f[j], f[i] }
+func (f *filaCaminho) Push(x any)   { *f = append(*f, x.(noCaminho)) }
+func (f *filaCaminho) Pop() any {
+	antigo := *f
+	no := antigo[len(antigo)-1]
+	*f = antigo[:len(antigo)-1]
+	return no
+}
+
+// ============================================================================
+// MÓDULO DE CACHE DE CAMINHOS
+// ============================================================================
+
+// Retorna o próximo passo do inimigo no caminho até o alvo
+// Usa o caminho guardado enquanto o alvo não muda de célula, o mapa não
+// muda e o inimigo continua sobre ele; caso contrário, recalcula com A*.
+// Retorna false se não houver caminho
+func caminhoProximoPasso(jogo *Jogo, id int, cache *CaminhoInimigo, origem, alvo Ponto) (Ponto, bool) {
+	if cache.Alvo == alvo && cache.Versao == jogo.VersaoMapa {
+		for i, p := range cache.Passos {
+			if p == origem && i+1 < len(cache.Passos) {
+				proximo := cache.Passos[i+1]
+				if jogoPodeMoverParaInimigo(jogo, proximo.X, proximo.Y, id) {
+					cache.Passos = cache.Passos[i:] // Descarta o trecho já percorrido
+					return proximo, true
+				}
+				break // Passo bloqueado: recalcula
+			}
+		}
+	}
+
+	cache.Alvo, cache.Versao = alvo, jogo.VersaoMapa
+	cache.Passos = caminhoAEstrela(jogo, id, origem, alvo)
+	if len(cache.Passos) < 2 {
+		return Ponto{}, false
+	}
+	return cache.Passos[1], true
+}
@@ -0,0 +1,53 @@
+// caminho_test.go - Testes da busca de caminhos e do caminho guardado
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// O menor caminho contorna a parede pela abertura mais próxima, e não há
+// caminho até uma célula cercada de paredes
+func TestCaminhoAEstrela(t *testing.T) {
+	jogo := testeCarregarMapa(t, "testdata/caminho.txt")
+	id := testeBuscarTipo(t, &jogo, TipoInimigo)
+
+	caminho := caminhoAEstrela(&jogo, id, Ponto{3, 1}, Ponto{5, 1})
+	if len(caminho) != 9 || caminho[0] != (Ponto{3, 1}) || caminho[8] != (Ponto{5, 1}) || !slices.Contains(caminho, Ponto{4, 4}) {
+		t.Errorf("caminho em volta da parede %v, esperados 8 passos pela abertura em (4, 4)", caminho)
+	}
+	for i := 1; i < len(caminho); i++ {
+		if caminhoHeuristica(caminho[i-1], caminho[i]) != 1 || !jogoPodeMoverParaInimigo(&jogo, caminho[i].X, caminho[i].Y, id) {
+			t.Errorf("passo inválido de %v para %v", caminho[i-1], caminho[i])
+		}
+	}
+
+	if caminho := caminhoAEstrela(&jogo, id, Ponto{3, 1}, Ponto{9, 1}); caminho != nil {
+		t.Errorf("caminho até a célula cercada (9, 1): %v", caminho)
+	}
+	var cache CaminhoInimigo
+	if passo, ok := caminhoProximoPasso(&jogo, id, &cache, Ponto{3, 1}, Ponto{9, 1}); ok {
+		t.Errorf("passo até a célula cercada: %v", passo)
+	}
+}
+
+// O caminho guardado é usado enquanto nada muda, e recalculado quando a
+// parede frágil cai e abre um atalho
+func TestCaminhoGuardadoMapaMuda(t *testing.T) {
+	jogo := testeCarregarMapa(t, "testdata/caminho.txt")
+	id := testeBuscarTipo(t, &jogo, TipoInimigo)
+	origem, alvo := Ponto{3, 1}, Ponto{5, 1}
+
+	var cache CaminhoInimigo
+	passo, _ := caminhoProximoPasso(&jogo, id, &cache, origem, alvo)
+	guardado := cache.Passos
+	if proximo, _ := caminhoProximoPasso(&jogo, id, &cache, passo, alvo); &cache.Passos[0] != &guardado[1] || proximo != guardado[2] {
+		t.Errorf("caminho recalculado sem o mapa mudar: %v", cache.Passos)
+	}
+
+	jogoTrocarCelula(&jogo, 4, 3, Vazio)
+	caminhoProximoPasso(&jogo, id, &cache, guardado[2], alvo)
+	if len(cache.Passos) != 5 || !slices.Contains(cache.Passos, Ponto{4, 3}) {
+		t.Errorf("caminho depois de a parede cair %v, esperado o atalho por (4, 3)", cache.Passos)
+	}
+}
@@ -0,0 +1,120 @@
+// campanha.go - Campanhas: sequências de mapas jogadas em ordem
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Tempo em que a tela de apresentação de cada nível fica visível
+const DuracaoIntroducao = 2 * time.Second
+
+// Campanha é uma lista ordenada de mapas lida de um arquivo de manifesto
+type Campanha struct {
+	Arquivo string   // arquivo de manifesto
+	Nome    string   // nome exibido na apresentação dos níveis
+	Mapas   []string // arquivos de mapa, na ordem em que são jogados
+}
+
+// InfoCampanha descreve, dentro do Jogo, o nível de campanha em andamento
+type InfoCampanha struct {
+	Arquivo   string // arquivo de manifesto (vazio fora de campanhas)
+	Nome      string // nome da campanha
+	NomeNivel string // nome do mapa do nível atual
+	Nivel     int    // nível atual, a partir de 1
+	Total     int    // quantidade de níveis da campanha
+	Concluida bool   // o último nível foi vencido
+}
+
+// ============================================================================
+// MÓDULO DE LEITURA DO MANIFESTO
+// ============================================================================
+
+// Lê um manifesto de campanha
+// Cada linha é um arquivo de mapa, relativo ao diretório do manifesto; uma
+// linha "nome: ..." opcional dá nome à campanha:
+//
+//	# comentário
+//	nome: Aventura
+//	mapa.txt
+//	maze.txt
+//
+// Todos os mapas são validados na leitura, para que um erro no último nível
+// não apareça só depois de vencer os anteriores
+func campanhaCarregar(nome string) (*Campanha, error) {
+	arq, err := os.Open(nome)
+	if err != nil {
+		return nil, err
+	}
+	defer arq.Close()
+
+	campanha := &Campanha{Arquivo: nome, Nome: filepath.Base(nome)}
+	scanner := bufio.NewScanner(arq)
+	numLinha := 0
+	for scanner.Scan() {
+		numLinha++
+		linha := strings.TrimSpace(scanner.Text())
+		if linha == "" || strings.HasPrefix(linha, "#") {
+			continue // Ignora linhas vazias e comentários
+		}
+
+		if valor, ok := strings.CutPrefix(linha, "nome:"); ok {
+			campanha.Nome = strings.TrimSpace(valor)
+			continue
+		}
+
+		mapa := linha
+		if !filepath.IsAbs(mapa) {
+			mapa = filepath.Join(filepath.Dir(nome), mapa)
+		}
+		conteudo, err := mapaLer(mapa)
+		if err == nil {
+			err = mapaValidar(mapa, conteudo)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: mapa inválido:\n%v", nome, numLinha, err)
+		}
+		campanha.Mapas = append(campanha.Mapas, mapa)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(campanha.Mapas) == 0 {
+		return nil, fmt.Errorf("%s: a campanha não tem mapas", nome)
+	}
+	return campanha, nil
+}
+
+// ============================================================================
+// MÓDULO DE PROGRESSÃO DE NÍVEIS
+// ============================================================================
+
+// Monta o jogo do nível informado (a partir de 1), continuando a partida
+// anterior (veja jogoContinuar) e começando pela tela de apresentação
+func jogoCarregarNivel(anterior *Jogo, campanha *Campanha, nivel int) (Jogo, error) {
+	jogo := jogoContinuar(anterior)
+	if err := jogoCarregarMapa(campanha.Mapas[nivel-1], &jogo); err != nil {
+		return Jogo{}, err
+	}
+
+	// O nível é chamado pelo nome do cabeçalho do mapa ou pelo nome do arquivo
+	nomeNivel := jogo.Cabecalho.Nome
+	if nomeNivel == "" {
+		nomeNivel = filepath.Base(campanha.Mapas[nivel-1])
+	}
+
+	jogo.Campanha = InfoCampanha{
+		Arquivo:   campanha.Arquivo,
+		Nome:      campanha.Nome,
+		NomeNivel: nomeNivel,
+		Nivel:     nivel,
+		Total:     len(campanha.Mapas),
+	}
+	jogo.IntroducaoAte = jogo.Tempo.Add(DuracaoIntroducao)
+	jogo.StatusMsg = fmt.Sprintf("Nível %d de %d", nivel, len(campanha.Mapas))
+	return jogo, nil
+}
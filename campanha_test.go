@@ -0,0 +1,47 @@
+// campanha_test.go - Testes da progressão dos níveis de uma campanha
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// Vencido o primeiro nível, a simulação carrega o segundo com a vida e a
+// pontuação da partida e mostra a sua apresentação
+func TestCampanhaProximoNivel(t *testing.T) {
+	campanha, err := campanhaCarregar("testdata/campanha.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	inicio := jogoNovo()
+	inicio.Tempo = relogioSimuladoNovo().Agora()
+	jogo, err := jogoCarregarNivel(&inicio, campanha, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Um passo para a direita, depois da apresentação, coleta a única cura; a
+	// troca de forma da bomba só mantém a partida até o nível seguinte
+	roteiro := []EventoTeclado{
+		{Tipo: "mover", Tecla: 'd', Tempo: DuracaoIntroducao + 100*time.Millisecond},
+		{Tipo: "forma", Tempo: DuracaoIntroducao + 200*time.Millisecond},
+	}
+	config := ConfigSimulacao{Intervalo: IntervaloTickTeste, Semente: 1, Deterministico: true, Campanha: campanha}
+	sim := simulacaoNova(config, fonteRoteiroNova(roteiro))
+	go simulacaoExecutar(sim, jogo)
+	var final Jogo
+	for quadro := range sim.quadros {
+		final = quadro
+	}
+
+	info := final.Campanha
+	if info.Nivel != 2 || info.Total != 2 || info.NomeNivel != "Teste do fantasma" || info.Concluida {
+		t.Fatalf("campanha depois do primeiro nível: %+v", info)
+	}
+	if final.JogoTerminado || !jogoEmIntroducao(&final) || final.StatusMsg != "Nível 2 de 2" {
+		t.Errorf("segundo nível sem apresentação: terminado %v, mensagem %q", final.JogoTerminado, final.StatusMsg)
+	}
+	if final.Pontos != PontosCura+PontosNivel || final.Vida != jogo.Vida+1 {
+		t.Errorf("pontos %d e vida %d, esperados %d e %d", final.Pontos, final.Vida, PontosCura+PontosNivel, jogo.Vida+1)
+	}
+}
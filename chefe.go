@@ -0,0 +1,192 @@
+// chefe.go - Chefe: inimigo grande, com barra de vida e fases de ataque
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// Ataques do chefe
+const (
+	PassosInvestida  = 4               // células percorridas de uma vez em uma investida
+	RaioPisao        = 2               // alcance do pisão ao redor do chefe
+	LimiteInvocados  = 3               // lacaios do chefe vivos ao mesmo tempo
+	RecargaInvestida = 3 * time.Second // tempo entre duas investidas
+	RecargaInvocacao = 4 * time.Second // tempo entre duas invocações
+	RecargaPisao     = 3 * time.Second // tempo entre dois pisões
+)
+
+// Pontos pelo chefe eliminado (no lugar dos pontos de um inimigo comum)
+const PontosChefe = 1000
+
+// Sprite do chefe, repetido em todas as células que ele ocupa
+var Chefe = Elemento{'♛', CorVermelho, CorPadrao, true}
+
+// FaseChefe é o padrão de ataque do chefe, que muda conforme ele perde vida
+type FaseChefe int
+
+const (
+	FaseInvestida FaseChefe = iota // avança várias células de uma vez quando alinhado
+	FaseInvocacao                  // invoca lacaios ao seu lado
+	FasePisao                      // pisa no chão e atinge tudo ao seu redor
+)
+
+func (f FaseChefe) String() string {
+	switch f {
+	case FaseInvestida:
+		return "Investida"
+	case FaseInvocacao:
+		return "Invocação"
+	}
+	return "Pisão"
+}
+
+// ============================================================================
+// MÓDULO DE CONSULTA DO CHEFE
+// ============================================================================
+
+// Calcula a fase do chefe pela vida que lhe resta
+// Acima de dois terços da vida ele investe, acima de um terço invoca
+// lacaios e, no último terço, pisa. A fase não precisa ser salva
+func chefeFase(ent Entidade) FaseChefe {
+	maxima := arquetipoDe(ent).Vida
+	switch {
+	case ent.Vida*3 > maxima*2:
+		return FaseInvestida
+	case ent.Vida*3 > maxima:
+		return FaseInvocacao
+	}
+	return FasePisao
+}
+
+// Procura o primeiro chefe vivo do mapa
+// Retorna seu índice em jogo.Entidades ou -1 se não há chefe
+func jogoBuscarChefe(jogo *Jogo) int {
+	for i, ent := range jogo.Entidades[1:] {
+		if arquetipoDe(ent).Chefe {
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// Distância Manhattan da célula (x, y) até a célula mais próxima ocupada
+// pela entidade (zero se ela ocupa a célula)
+func chefeDistancia(ent Entidade, x, y int) int {
+	t := arquetipoTamanho(ent)
+	dx := max(0, ent.X-x, x-(ent.X+t-1))
+	dy := max(0, ent.Y-y, y-(ent.Y+t-1))
+	return dx + dy
+}
+
+// ============================================================================
+// MÓDULO DE ATAQUES
+// ============================================================================
+
+// Decide o ataque especial do chefe, conforme a sua fase
+// O chefe só ataca perseguindo o personagem que enxerga e com o ataque
+// recarregado. O dano é calculado ao executar o comando, na posição em que
+// o chefe de fato para. Retorna false se nenhum ataque é possível agora (o
+// chefe então anda como os demais)
+func chefeAtacar(jogo *Jogo, idx int, mente *MenteInimigo, visto bool) (ComandoMoverInimigo, bool) {
+	ent, p := jogo.Entidades[idx], jogo.Entidades[0]
+	if !arquetipoDe(ent).Chefe || mente.Estado != EstadoPerseguindo || !visto || jogo.Tempo.Before(mente.ProximoAtaque) {
+		return ComandoMoverInimigo{}, false
+	}
+
+	switch chefeFase(ent) {
+	case FaseInvestida:
+		direcao, passos := chefeInvestida(jogo, idx)
+		if passos == 0 {
+			return ComandoMoverInimigo{}, false
+		}
+		mente.ProximoAtaque = jogo.Tempo.Add(RecargaInvestida)
+		return ComandoMoverInimigo{ID: ent.ID, DX: direcao.X, DY: direcao.Y, Passos: passos, Log: fmt.Sprintf("Investida! (%d passos)", passos)}, true
+
+	case FaseInvocacao:
+		if ninhoContarInimigos(jogo, ent.ID) >= LimiteInvocados {
+			return ComandoMoverInimigo{}, false
+		}
+		mente.ProximoAtaque = jogo.Tempo.Add(RecargaInvocacao)
+		return ComandoMoverInimigo{ID: ent.ID, Invocar: true, Log: "Invocando lacaio"}, true
+	}
+
+	if chefeDistancia(ent, p.X, p.Y) > RaioPisao {
+		return ComandoMoverInimigo{}, false
+	}
+	mente.ProximoAtaque = jogo.Tempo.Add(RecargaPisao)
+	return ComandoMoverInimigo{ID: ent.ID, Pisao: true, Log: "Pisão!"}, true
+}
+
+// Calcula a investida do chefe em direção ao personagem
+// O personagem precisa estar em uma das linhas ou colunas ocupadas pelo
+// chefe. O chefe avança até PassosInvestida células e para ao bater em
+// algo ou ao alcançar o personagem. Retorna a direção e as células
+// percorridas
+func chefeInvestida(jogo *Jogo, idx int) (Ponto, int) {
+	ent, p := jogo.Entidades[idx], jogo.Entidades[0]
+	t := arquetipoTamanho(ent)
+
+	var direcao Ponto
+	switch {
+	case p.Y >= ent.Y && p.Y < ent.Y+t:
+		direcao.X = projetilSinal(p.X - ent.X)
+	case p.X >= ent.X && p.X < ent.X+t:
+		direcao.Y = projetilSinal(p.Y - ent.Y)
+	default:
+		return Ponto{}, 0 // Desalinhado
+	}
+
+	passos := 0
+	for passos < PassosInvestida && jogoPodeMoverParaInimigo(jogo, ent.X+direcao.X, ent.Y+direcao.Y, ent.ID) {
+		ent.X += direcao.X
+		ent.Y += direcao.Y
+		passos++
+		if jogoEntidadeOcupa(ent, p.X, p.Y) {
+			break
+		}
+	}
+	return direcao, passos
+}
+
+// Cria um lacaio (inimigo comum) em uma célula livre ao redor do chefe
+// As células são testadas em ordem fixa, da linha de cima para a de baixo;
+// se todas estão ocupadas, nenhum lacaio surge
+func chefeInvocar(jogo *Jogo, idx int) {
+	ent := jogo.Entidades[idx]
+	t := arquetipoTamanho(ent)
+	p := jogo.Entidades[0]
+
+	for y := ent.Y - 1; y <= ent.Y+t; y++ {
+		for x := ent.X - 1; x <= ent.X+t; x++ {
+			if jogoEntidadeOcupa(ent, x, y) || (x == p.X && y == p.Y) || !jogoPodeMoverParaPersonagem(jogo, x, y) {
+				continue
+			}
+			jogoAdicionarInimigo(jogo, TipoInimigo, Ponto{x, y}, ent.ID)
+			jogo.StatusMsg = "O chefe invocou um lacaio!"
+			return
+		}
+	}
+}
+
+// Mostra o pisão do chefe como explosões nas células ao seu redor e fere o
+// personagem dentro do alcance. As explosões do pisão não atingem inimigos
+// nem ninhos e ferem o personagem mesmo sem fogo amigo
+func chefePisotear(jogo *Jogo, idx int) {
+	ent := jogo.Entidades[idx]
+	t := arquetipoTamanho(ent)
+
+	for y := ent.Y - RaioPisao; y < ent.Y+t+RaioPisao; y++ {
+		for x := ent.X - RaioPisao; x < ent.X+t+RaioPisao; x++ {
+			if jogoEntidadeOcupa(ent, x, y) || chefeDistancia(ent, x, y) > RaioPisao || !jogoPodeMoverPara(jogo, x, y) {
+				continue
+			}
+			jogo.Explosoes = append(jogo.Explosoes, Explosao{X: x, Y: y, TempoVida: jogo.Tempo, Ativa: true, Pisao: true})
+		}
+	}
+	jogo.StatusMsg = "O chefe fez o chão tremer!"
+
+	if p := jogo.Entidades[0]; chefeDistancia(ent, p.X, p.Y) <= RaioPisao {
+		jogoAlterarVida(jogo, -arquetipoDe(ent).Dano)
+	}
+}
@@ -0,0 +1,68 @@
+// chefe_test.go - Testes dos ataques do chefe
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+// Decide o ataque do chefe no mapa de teste sobre um quadro do jogo e o
+// executa depois de o personagem ir para destino, como quando ele anda
+// entre o quadro e a resposta do chefe. Retorna a vida que sobrou
+func testeAtaqueChefe(t *testing.T, vidaChefe int, personagem, destino Ponto) int {
+	t.Helper()
+	jogo := testeCarregarMapa(t, "testdata/chefe.txt")
+	jogo.Entidades[0].X, jogo.Entidades[0].Y = personagem.X, personagem.Y
+	idx := jogoBuscarChefe(&jogo)
+	jogo.Entidades[idx].Vida = vidaChefe
+
+	quadro := jogoCopiar(&jogo)
+	cmd, ok := chefeAtacar(&quadro, idx, &MenteInimigo{Estado: EstadoPerseguindo}, true)
+	if !ok {
+		t.Fatalf("chefe na fase %v não atacou o personagem em %v", chefeFase(jogo.Entidades[idx]), personagem)
+	}
+
+	jogo.Entidades[0].X, jogo.Entidades[0].Y = destino.X, destino.Y
+	cmd.executar(&jogo)
+	return jogo.Vida
+}
+
+// O dano da investida e do pisão vem da posição em que o chefe para e da
+// posição do personagem ao executar o ataque, não do quadro em que o chefe
+// o decidiu
+func TestChefeFereOndeAtaca(t *testing.T) {
+	casos := []struct {
+		nome                string
+		vidaChefe           int
+		personagem, destino Ponto
+		vida                int
+	}{
+		{"investida alcança o personagem", 12, Ponto{2, 1}, Ponto{2, 1}, 1},
+		{"investida depois de o personagem sair da linha", 12, Ponto{2, 1}, Ponto{2, 3}, 3},
+		{"investida depois de o personagem entrar no caminho", 12, Ponto{1, 1}, Ponto{3, 1}, 1},
+		{"pisão com o personagem ao alcance", 3, Ponto{4, 1}, Ponto{4, 1}, 1},
+		{"pisão depois de o personagem se afastar", 3, Ponto{4, 1}, Ponto{2, 1}, 3},
+	}
+
+	for _, caso := range casos {
+		if vida := testeAtaqueChefe(t, caso.vidaChefe, caso.personagem, caso.destino); vida != caso.vida {
+			t.Errorf("%s: vida = %d, esperada %d", caso.nome, vida, caso.vida)
+		}
+	}
+}
+
+// O aviso da mudança de fase não é escondido pelo da bomba nem pelo das
+// células do chefe atingidas depois dela na mesma explosão
+func TestMensagemChefeMudouDeFase(t *testing.T) {
+	jogo := testeCarregarMapa(t, "testdata/chefe.txt")
+	chefe := &jogo.Entidades[jogoBuscarChefe(&jogo)]
+	chefe.Vida = 10
+
+	testeExplodirBomba(&jogo, 5, 1)
+	if chefe.Vida != 6 {
+		t.Fatalf("vida do chefe = %d, esperada 6 (as quatro células atingidas)", chefe.Vida)
+	}
+	if esperada := fmt.Sprintf("O chefe mudou de fase: %v!", FaseInvocacao); jogo.StatusMsg != esperada {
+		t.Errorf("mensagem = %q, esperada %q", jogo.StatusMsg, esperada)
+	}
+}
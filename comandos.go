@@ -0,0 +1,158 @@
+// comandos.go - Mensagens enviadas à goroutine dona do estado do jogo
+package main
+
+import "time"
+
+// ============================================================================
+// DEFINIÇÃO DOS COMANDOS
+// ============================================================================
+
+// Comando é uma mensagem que altera o estado do jogo
+// Apenas a goroutine da simulação executa comandos; os demais elementos
+// apenas os enviam (os inimigos, projéteis, ninhos e o fogo como resposta
+// na sua vez de agir).
+// Ações do jogador chegam como EventoTeclado e dano/cura pelo canal chanVida
+type Comando interface {
+	executar(jogo *Jogo)
+}
+
+// Move um inimigo em uma célula e atualiza seu log de comportamento
+type ComandoMoverInimigo struct {
+	ID      int          // identificador da entidade do inimigo
+	DX, DY  int          // deslocamento desejado
+	Log     string       // descrição do comportamento atual
+	Disparo Ponto        // direção do projétil disparado (zero: nenhum disparo)
+	Passos  int          // células percorridas na direção (DX, DY) (zero: uma; investida do chefe)
+	Invocar bool         // o chefe invoca um lacaio ao seu lado
+	Pisao   bool         // o chefe pisa no chão e atinge as células ao seu redor
+	Mente   MenteInimigo // memória do inimigo depois desta vez
+}
+
+// Resposta de um ninho na sua vez: gera ou não um novo inimigo ao seu lado
+type ComandoGerarInimigo struct {
+	Ninho   int       // identificador do ninho
+	Gerar   bool      // o ninho decidiu gerar um inimigo
+	Proximo time.Time // instante da próxima geração pelo tempo
+}
+
+// Move um projétil uma célula na sua direção ou o remove quando ele para
+type ComandoMoverProjetil struct {
+	ID      int  // identificador do projétil
+	Remover bool // o projétil atingiu o personagem ou uma parede
+}
+
+// Resposta do fogo na sua vez: chamas que se apagam, que queimam o que está
+// na célula e vegetação que pega fogo
+type ComandoFogo struct {
+	Apagar  []Ponto // chamas que se apagam
+	Queimar []Ponto // chamas que atingem os inimigos na sua célula
+	Acender []Ponto // vegetação vizinha que pega fogo
+}
+
+// Resposta das explosões na sua vez, depois de enviar o dano ao personagem
+// Avisa o jogador se o dano foi aplicado (fora do intervalo entre danos)
+type ComandoAtingirPersonagem struct {
+	Causa string // complemento da mensagem, ex.: "pela explosão" (vazio: nada atingido)
+}
+
+// ============================================================================
+// EXECUÇÃO DOS COMANDOS
+// ============================================================================
+
+func (c ComandoMoverInimigo) executar(jogo *Jogo) {
+	// O inimigo pode ter sido removido depois que o comando foi enviado
+	idx := jogoBuscarEntidade(jogo, c.ID)
+	if idx <= 0 {
+		return
+	}
+
+	ent := &jogo.Entidades[idx]
+	ent.Mente = c.Mente
+	p := jogo.Entidades[0]
+	for range max(c.Passos, 1) {
+		if (c.DX == 0 && c.DY == 0) || !jogoPodeMoverParaInimigo(jogo, ent.X+c.DX, ent.Y+c.DY, c.ID) {
+			break
+		}
+		jogoMoverElemento(jogo, ent.X, ent.Y, c.DX, c.DY, ent)
+		if c.Passos > 0 && jogoEntidadeOcupa(*ent, p.X, p.Y) {
+			// A investida para no personagem, onde o chefe de fato o alcança
+			jogoAlterarVida(jogo, -arquetipoDe(*ent).Dano)
+			break
+		}
+	}
+
+	// Ataques do chefe
+	if c.Invocar {
+		chefeInvocar(jogo, idx)
+	}
+	if c.Pisao {
+		chefePisotear(jogo, idx)
+	}
+
+	// Cria o projétil disparado; a simulação inicia sua goroutine
+	if c.Disparo != (Ponto{}) {
+		jogo.Projeteis = append(jogo.Projeteis, Projetil{
+			ID:   jogoNovoID(jogo),
+			X:    ent.X,
+			Y:    ent.Y,
+			DX:   c.Disparo.X,
+			DY:   c.Disparo.Y,
+			Dano: arquetipoDe(*ent).Dano,
+		})
+	}
+
+	// Atualiza log de comportamento
+	if c.Log != "" {
+		jogo.LogsInimigos[c.ID] = c.Log
+	}
+}
+
+func (c ComandoGerarInimigo) executar(jogo *Jogo) {
+	// O ninho pode ter sido destruído depois que o comando foi enviado
+	idx := jogoBuscarNinho(jogo, c.Ninho)
+	if idx < 0 {
+		return
+	}
+	jogo.Ninhos[idx].Proximo = c.Proximo
+	if !c.Gerar {
+		return
+	}
+
+	pos, ok := ninhoSaida(jogo, jogo.Ninhos[idx])
+	if !ok {
+		return // Sem espaço ao redor do ninho
+	}
+	jogoAdicionarInimigo(jogo, jogo.Cabecalho.InimigoNinhos, pos, c.Ninho)
+}
+
+func (c ComandoMoverProjetil) executar(jogo *Jogo) {
+	idx := jogoBuscarProjetil(jogo, c.ID)
+	if idx < 0 {
+		return
+	}
+
+	if c.Remover {
+		jogo.Projeteis = append(jogo.Projeteis[:idx], jogo.Projeteis[idx+1:]...)
+		return
+	}
+	jogo.Projeteis[idx].X += jogo.Projeteis[idx].DX
+	jogo.Projeteis[idx].Y += jogo.Projeteis[idx].DY
+}
+
+func (c ComandoFogo) executar(jogo *Jogo) {
+	for _, p := range c.Apagar {
+		jogoApagarChama(jogo, p.X, p.Y)
+	}
+	for _, p := range c.Queimar {
+		jogoAtingirInimigos(jogo, p.X, p.Y, "pelo fogo")
+	}
+	for _, p := range c.Acender {
+		jogoAcenderFogo(jogo, p.X, p.Y) // Ignora a vegetação que já pegou fogo
+	}
+}
+
+func (c ComandoAtingirPersonagem) executar(jogo *Jogo) {
+	if c.Causa != "" && jogo.UltimoDano.Equal(jogo.Tempo) {
+		jogo.StatusMsg = "Você foi atingido " + c.Causa + "!"
+	}
+}
@@ -0,0 +1,201 @@
+// entrada.go - Fontes de entrada que produzem as ações do jogador
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// FonteEntrada produz as ações do jogador em um canal
+// A simulação apenas lê do canal, sem saber se os eventos vêm do teclado,
+// de um roteiro em arquivo ou de outro trecho do programa. Quando o canal é
+// fechado, a simulação entende que o jogador saiu do jogo
+type FonteEntrada interface {
+	Eventos() <-chan EventoTeclado
+}
+
+// Converte uma tecla em ação do jogador
+// E coloca bomba, Q troca a forma da bomba, N gera um novo nível aleatório e
+// demais teclas movem
+func entradaEventoDeTecla(tecla rune) EventoTeclado {
+	// Detecta tecla E para colocar bomba
+	if tecla == 'e' || tecla == 'E' {
+		return EventoTeclado{Tipo: "bomba", Tecla: tecla}
+	}
+
+	// Detecta tecla Q para trocar a forma da explosão
+	if tecla == 'q' || tecla == 'Q' {
+		return EventoTeclado{Tipo: "forma", Tecla: tecla}
+	}
+
+	// Detecta tecla N para começar um nível gerado
+	if tecla == 'n' || tecla == 'N' {
+		return EventoTeclado{Tipo: "novo", Tecla: tecla}
+	}
+
+	// Para outras teclas, retorna como evento de movimento
+	return EventoTeclado{Tipo: "mover", Tecla: tecla}
+}
+
+// ============================================================================
+// MÓDULO DE ENTRADA PELO TECLADO (TERMBOX)
+// ============================================================================
+
+// FonteTermbox lê o teclado do terminal em uma goroutine própria
+type FonteTermbox struct {
+	eventos chan EventoTeclado
+}
+
+// Cria a fonte de teclado e começa a ler eventos do terminal
+func fonteTermboxNova() *FonteTermbox {
+	f := &FonteTermbox{eventos: make(chan EventoTeclado)}
+	go interfaceLerEventos(f.eventos)
+	return f
+}
+
+func (f *FonteTermbox) Eventos() <-chan EventoTeclado {
+	return f.eventos
+}
+
+// ============================================================================
+// MÓDULO DE ENTRADA PROGRAMADA
+// ============================================================================
+
+// FonteProgramada recebe eventos enviados pelo próprio programa
+// Útil para conduzir o jogo a partir de outro código (por exemplo, testes)
+type FonteProgramada struct {
+	eventos chan EventoTeclado
+}
+
+// Cria uma fonte programada vazia
+func fonteProgramadaNova() *FonteProgramada {
+	return &FonteProgramada{eventos: make(chan EventoTeclado)}
+}
+
+func (f *FonteProgramada) Eventos() <-chan EventoTeclado {
+	return f.eventos
+}
+
+// Envia um evento ao jogo, aguardando até que ele seja recebido
+func (f *FonteProgramada) Enviar(ev EventoTeclado) {
+	f.eventos <- ev
+}
+
+// Encerra a fonte; a simulação termina ao perceber o canal fechado
+func (f *FonteProgramada) Fechar() {
+	close(f.eventos)
+}
+
+// ============================================================================
+// MÓDULO DE ENTRADA POR ROTEIRO
+// ============================================================================
+
+// FonteRoteiro reproduz uma lista de eventos com horário marcado
+// Os eventos são entregues de imediato e a simulação aplica cada um no tick
+// em que o tempo de jogo atinge seu campo Tempo, de modo que o mesmo roteiro
+// sempre produz a mesma partida. Ao fim do roteiro o canal é fechado e o
+// jogo termina
+type FonteRoteiro struct {
+	eventos chan EventoTeclado
+}
+
+// Cria uma fonte que reproduz os eventos informados (em ordem de tempo)
+func fonteRoteiroNova(roteiro []EventoTeclado) *FonteRoteiro {
+	f := &FonteRoteiro{eventos: make(chan EventoTeclado)}
+	go f.reproduzir(roteiro)
+	return f
+}
+
+// Lê um arquivo de roteiro e cria a fonte que o reproduz
+func fonteRoteiroCarregar(nome string) (*FonteRoteiro, error) {
+	roteiro, err := entradaLerRoteiro(nome)
+	if err != nil {
+		return nil, err
+	}
+	return fonteRoteiroNova(roteiro), nil
+}
+
+func (f *FonteRoteiro) Eventos() <-chan EventoTeclado {
+	return f.eventos
+}
+
+// Envia os eventos do roteiro em ordem e fecha o canal no final
+func (f *FonteRoteiro) reproduzir(roteiro []EventoTeclado) {
+	defer close(f.eventos)
+
+	for _, ev := range roteiro {
+		f.eventos <- ev
+	}
+}
+
+// Lê um arquivo de roteiro de entrada
+// Cada linha tem o momento do evento desde o início do jogo e a tecla:
+//
+//	# comentário
+//	500ms d
+//	1s    e
+//	4.5s  esc
+//
+// Teclas aceitas: esc (sair), f5 (salvar), e (bomba), n (novo nível) ou
+// qualquer outro caractere (mover)
+func entradaLerRoteiro(nome string) ([]EventoTeclado, error) {
+	arq, err := os.Open(nome)
+	if err != nil {
+		return nil, err
+	}
+	defer arq.Close()
+
+	var roteiro []EventoTeclado
+	scanner := bufio.NewScanner(arq)
+	numLinha := 0
+	for scanner.Scan() {
+		numLinha++
+		linha := strings.TrimSpace(scanner.Text())
+		if linha == "" || strings.HasPrefix(linha, "#") {
+			continue // Ignora linhas vazias e comentários
+		}
+
+		campos := strings.Fields(linha)
+		if len(campos) != 2 {
+			return nil, fmt.Errorf("%s:%d: esperado \"<tempo> <tecla>\"", nome, numLinha)
+		}
+
+		tempo, err := time.ParseDuration(campos[0])
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: tempo inválido: %v", nome, numLinha, err)
+		}
+		if len(roteiro) > 0 && tempo < roteiro[len(roteiro)-1].Tempo {
+			return nil, fmt.Errorf("%s:%d: eventos fora de ordem", nome, numLinha)
+		}
+
+		ev, err := entradaEventoDeNome(campos[1])
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", nome, numLinha, err)
+		}
+		ev.Tempo = tempo
+		roteiro = append(roteiro, ev)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return roteiro, nil
+}
+
+// Converte o nome de uma tecla de roteiro em ação do jogador
+func entradaEventoDeNome(nome string) (EventoTeclado, error) {
+	if strings.EqualFold(nome, "esc") {
+		return EventoTeclado{Tipo: "sair"}, nil
+	}
+	if strings.EqualFold(nome, "f5") {
+		return EventoTeclado{Tipo: "salvar"}, nil
+	}
+
+	teclas := []rune(nome)
+	if len(teclas) != 1 {
+		return EventoTeclado{}, fmt.Errorf("tecla desconhecida %q", nome)
+	}
+	return entradaEventoDeTecla(teclas[0]), nil
+}
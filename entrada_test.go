@@ -0,0 +1,57 @@
+// entrada_test.go - Testes das fontes de entrada
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// Tempo máximo de espera pelo fim de uma partida conduzida pelo teste
+const EsperaFimPartida = 20 * time.Second
+
+// Conduz uma partida com semente pela fonte programada: o personagem
+// coloca uma bomba ao lado do inimigo preso entre paredes frágeis, se
+// afasta do alcance da explosão e vence quando a bomba explode
+func TestFonteProgramadaJogaPartida(t *testing.T) {
+	jogo := testeCarregarMapa(t, "testdata/programada.txt")
+	fonte := fonteProgramadaNova()
+	config := ConfigSimulacao{Intervalo: IntervaloTickTeste, Semente: 7, Deterministico: true}
+	sim := simulacaoNova(config, fonte)
+	go simulacaoExecutar(sim, jogo)
+
+	// Cada envio aguarda a simulação receber o evento
+	for _, tecla := range "sdddddeaaaa" {
+		fonte.Enviar(entradaEventoDeTecla(tecla))
+	}
+
+	// Aguarda a vitória e encerra a partida pela fonte
+	var ultimo Jogo
+	limite := time.After(EsperaFimPartida)
+	for !ultimo.JogoTerminado {
+		select {
+		case ultimo = <-sim.quadros:
+		case <-limite:
+			t.Fatalf("a partida não terminou em %v; último estado: %q", EsperaFimPartida, ultimo.StatusMsg)
+		}
+	}
+	fonte.Fechar()
+	for quadro := range sim.quadros {
+		ultimo = quadro
+	}
+
+	if !ultimo.Venceu {
+		t.Errorf("partida terminou sem vitória: %q", ultimo.StatusMsg)
+	}
+	if ultimo.Pontos != PontosInimigo {
+		t.Errorf("pontos = %d, esperado %d", ultimo.Pontos, PontosInimigo)
+	}
+	if ultimo.Vida != 3 {
+		t.Errorf("vida = %d, esperada 3 (o personagem saiu do alcance da bomba)", ultimo.Vida)
+	}
+	if p := ultimo.Entidades[0]; p.X != 2 || p.Y != 2 {
+		t.Errorf("personagem em (%d, %d), esperado em (2, 2)", p.X, p.Y)
+	}
+	if ultimo.Direcao != 'a' {
+		t.Errorf("direção = %q, esperada 'a'", ultimo.Direcao)
+	}
+}
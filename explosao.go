@@ -0,0 +1,207 @@
+// explosao.go - Formas das explosões das bombas
+package main
+
+// Formas de explosão aceitas no cabeçalho (forma_bomba) e nas bombas
+const (
+	FormaLosango = "losango" // células a até raio casas (distância Manhattan), através das paredes
+	FormaCruz    = "cruz"    // quatro raios em linha reta que param na primeira parede
+	FormaCirculo = "circulo" // raios lançados em todas as direções até a parede ou o raio
+)
+
+// Formas na ordem em que o jogador as alterna com a tecla Q
+var FormasBomba = []string{FormaLosango, FormaCruz, FormaCirculo}
+
+// ============================================================================
+// MÓDULO DE CÁLCULO DAS CÉLULAS ATINGIDAS
+// ============================================================================
+
+// Calcula as células atingidas pela explosão de uma bomba em (x, y)
+// A forma vazia (bombas de salvamentos antigos) é o losango. As células
+// são retornadas em ordem fixa, para que a partida seja reproduzível
+func explosaoCelulas(jogo *Jogo, x, y int, forma string, raio int) []Ponto {
+	switch forma {
+	case FormaCruz:
+		return explosaoCruz(jogo, x, y, raio)
+	case FormaCirculo:
+		return explosaoCirculo(jogo, x, y, raio)
+	}
+	return explosaoLosango(jogo, x, y, raio)
+}
+
+// Indica se a explosão pode ocupar a célula (dentro do mapa e sem parede)
+// A parede com um fantasma dentro é ocupada, para que ele possa ser atingido
+func explosaoCelulaLivre(jogo *Jogo, x, y int) bool {
+	if y < 0 || y >= len(jogo.Mapa) || x < 0 || x >= len(jogo.Mapa[y]) {
+		return false
+	}
+	return jogo.Mapa[y][x].simbolo != Parede.simbolo || explosaoFantasmaNaParede(jogo, x, y)
+}
+
+// Indica se há um inimigo que atravessa paredes na célula (x, y)
+func explosaoFantasmaNaParede(jogo *Jogo, x, y int) bool {
+	for _, ent := range jogo.Entidades[1:] {
+		if arquetipoDe(ent).Atravessa && jogoEntidadeOcupa(ent, x, y) {
+			return true
+		}
+	}
+	return false
+}
+
+// Losango: todas as células a até raio casas de distância Manhattan
+// As paredes não são atingidas (exceto com um fantasma dentro), mas também
+// não protegem o que está atrás
+func explosaoLosango(jogo *Jogo, x, y, raio int) []Ponto {
+	var celulas []Ponto
+	for dx := -raio; dx <= raio; dx++ {
+		for dy := -raio; dy <= raio; dy++ {
+			if abs(dx)+abs(dy) <= raio && explosaoCelulaLivre(jogo, x+dx, y+dy) {
+				celulas = append(celulas, Ponto{x + dx, y + dy})
+			}
+		}
+	}
+	return celulas
+}
+
+// Cruz: o centro e quatro raios em linha reta de até raio casas
+// Cada raio para antes da primeira parede ou na primeira parede frágil ou
+// parede com um fantasma dentro
+func explosaoCruz(jogo *Jogo, x, y, raio int) []Ponto {
+	var celulas []Ponto
+	if explosaoCelulaLivre(jogo, x, y) {
+		celulas = append(celulas, Ponto{x, y})
+	}
+	for _, d := range direcoesCaminho {
+		for i := 1; i <= raio; i++ {
+			cx, cy := x+d.X*i, y+d.Y*i
+			if !explosaoCelulaLivre(jogo, cx, cy) {
+				break
+			}
+			celulas = append(celulas, Ponto{cx, cy})
+			if jogoEhParede(jogo.Mapa[cy][cx]) {
+				break // A parede atingida segura o resto do raio
+			}
+		}
+	}
+	return celulas
+}
+
+// Círculo: raios lançados do centro até cada célula da borda do quadrado
+// de lado 2*raio+1, com o algoritmo de Bresenham. Cada raio atinge as
+// células a até raio casas (distância euclidiana) e para na primeira parede
+// (ou na primeira parede frágil ou com um fantasma dentro, que é atingida)
+func explosaoCirculo(jogo *Jogo, x, y, raio int) []Ponto {
+	atingidas := map[Ponto]bool{{x, y}: explosaoCelulaLivre(jogo, x, y)}
+	for dy := -raio; dy <= raio; dy++ {
+		for dx := -raio; dx <= raio; dx++ {
+			if abs(dx) == raio || abs(dy) == raio {
+				explosaoLancarRaio(jogo, x, y, x+dx, y+dy, raio, atingidas)
+			}
+		}
+	}
+
+	// Ordem fixa: linha a linha, de cima para baixo
+	var celulas []Ponto
+	for cy := y - raio; cy <= y+raio; cy++ {
+		for cx := x - raio; cx <= x+raio; cx++ {
+			if atingidas[Ponto{cx, cy}] {
+				celulas = append(celulas, Ponto{cx, cy})
+			}
+		}
+	}
+	return celulas
+}
+
+// Percorre a reta de (x1, y1) até (x2, y2), marcando as células atingidas
+// até sair do raio ou encontrar uma parede ou o fim do mapa
+func explosaoLancarRaio(jogo *Jogo, x1, y1, x2, y2, raio int, atingidas map[Ponto]bool) {
+	dx, dy := abs(x2-x1), -abs(y2-y1)
+	sx, sy := projetilSinal(x2-x1), projetilSinal(y2-y1)
+	erro := dx + dy
+
+	x, y := x1, y1
+	for x != x2 || y != y2 {
+		e2 := 2 * erro
+		if e2 >= dy {
+			erro += dy
+			x += sx
+		}
+		if e2 <= dx {
+			erro += dx
+			y += sy
+		}
+
+		if (x-x1)*(x-x1)+(y-y1)*(y-y1) > raio*raio || !explosaoCelulaLivre(jogo, x, y) {
+			return
+		}
+		atingidas[Ponto{x, y}] = true
+		if jogoEhParede(jogo.Mapa[y][x]) {
+			return // A parede atingida segura o resto do raio
+		}
+	}
+}
+
+// ============================================================================
+// MÓDULO DE FOGO AMIGO
+// ============================================================================
+
+// Indica se o personagem está em uma explosão ativa que o fere
+// As bombas só o ferem se o mapa tem fogo amigo; o pisão do chefe, sempre.
+// Retorna também o complemento da mensagem ("pela explosão")
+func explosaoAtingePersonagem(jogo *Jogo) (string, bool) {
+	if jogo.Vida <= 0 || jogo.JogoTerminado {
+		return "", false
+	}
+	p := jogo.Entidades[0]
+	for _, e := range jogo.Explosoes {
+		if !e.Ativa || e.X != p.X || e.Y != p.Y {
+			continue
+		}
+		if e.Pisao {
+			return "pelo pisão do chefe", true
+		}
+		if jogo.Cabecalho.FogoAmigo {
+			return "pela explosão", true
+		}
+	}
+	return "", false
+}
+
+// Goroutine das explosões: a cada tick com explosões ativas, envia dano pelo
+// canal chanVida se o personagem estiver em uma que o fere
+func explosaoExecutar(vez <-chan Jogo, sair <-chan struct{}, acoes chan<- Comando, chanVida chan<- int) {
+	simulacaoAgirNaVez(vez, sair, acoes, func(quadro *Jogo) Comando {
+		causa, ok := explosaoAtingePersonagem(quadro)
+		if !ok {
+			return ComandoAtingirPersonagem{}
+		}
+		chanVida <- -1 // O dano respeita o intervalo entre danos
+		return ComandoAtingirPersonagem{Causa: causa}
+	})
+}
+
+// ============================================================================
+// MÓDULO DE ESCOLHA DA FORMA
+// ============================================================================
+
+// Passa para a próxima forma de bomba (tecla Q)
+// As bombas já colocadas mantêm a forma com que foram colocadas
+func jogoTrocarFormaBomba(jogo *Jogo) {
+	proxima := FormasBomba[0]
+	for i, forma := range FormasBomba {
+		if forma == jogo.FormaBomba {
+			proxima = FormasBomba[(i+1)%len(FormasBomba)]
+		}
+	}
+	jogo.FormaBomba = proxima
+	jogo.StatusMsg = "Bomba em " + proxima
+}
+
+// Indica se a forma de explosão é conhecida
+func explosaoFormaValida(forma string) bool {
+	for _, f := range FormasBomba {
+		if f == forma {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,184 @@
+// explosao_test.go - Testes das formas das explosões
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// Retorna o ID do primeiro inimigo do tipo informado no mapa de teste
+func testeBuscarTipo(t *testing.T, jogo *Jogo, tipo string) int {
+	t.Helper()
+	for _, ent := range jogo.Entidades[1:] {
+		if ent.Tipo == tipo {
+			return ent.ID
+		}
+	}
+	t.Fatalf("mapa de teste sem inimigo do tipo %q", tipo)
+	return 0
+}
+
+// Coloca o fantasma do mapa de teste dentro da parede (x, y)
+func testeFantasmaNaParede(t *testing.T, jogo *Jogo, x, y int) int {
+	t.Helper()
+	id := testeBuscarTipo(t, jogo, TipoFantasma)
+	ent := &jogo.Entidades[jogoBuscarEntidade(jogo, id)]
+	ent.X, ent.Y, ent.UltimoVisitado = x, y, jogo.Mapa[y][x]
+	return id
+}
+
+// Fantasmas atravessam paredes e paredes frágeis; os demais inimigos não
+func TestFantasmaAtravessaParedes(t *testing.T) {
+	jogo := testeCarregarMapa(t, "testdata/fantasma.txt")
+	fantasma := testeBuscarTipo(t, &jogo, TipoFantasma)
+	comum := testeBuscarTipo(t, &jogo, TipoInimigo)
+
+	for _, p := range []Ponto{{3, 2}, {3, 3}} {
+		if !jogoPodeMoverParaInimigo(&jogo, p.X, p.Y, fantasma) {
+			t.Errorf("fantasma não entra na parede %q em %v", jogo.Mapa[p.Y][p.X].simbolo, p)
+		}
+		if jogoPodeMoverParaInimigo(&jogo, p.X, p.Y, comum) {
+			t.Errorf("inimigo comum entra na parede %q em %v", jogo.Mapa[p.Y][p.X].simbolo, p)
+		}
+	}
+}
+
+// Um fantasma dentro da parede é atingido por toda forma de explosão que
+// alcança a parede, e a parede continua segurando os raios da cruz e do círculo
+func TestExplosaoAtingeFantasmaNaParede(t *testing.T) {
+	for _, forma := range FormasBomba {
+		jogo := testeCarregarMapa(t, "testdata/fantasma.txt")
+		celulas := explosaoCelulas(&jogo, 3, 1, forma, 2)
+		if slices.Contains(celulas, Ponto{3, 2}) {
+			t.Errorf("%s: explosão ocupou a parede vazia (3, 2)", forma)
+		}
+
+		fantasma := testeFantasmaNaParede(t, &jogo, 3, 2)
+		celulas = explosaoCelulas(&jogo, 3, 1, forma, 2)
+		if !slices.Contains(celulas, Ponto{3, 2}) {
+			t.Errorf("%s: explosão não alcançou o fantasma na parede: %v", forma, celulas)
+		}
+		if forma != FormaLosango && slices.Contains(celulas, Ponto{3, 3}) {
+			t.Errorf("%s: explosão passou da parede com o fantasma: %v", forma, celulas)
+		}
+
+		jogoExplodirBomba(&jogo, Bomba{X: 3, Y: 1, Ativa: true, Forma: forma})
+		if jogoBuscarEntidade(&jogo, fantasma) >= 0 {
+			t.Errorf("%s: fantasma na parede sobreviveu à explosão", forma)
+		}
+		if jogo.Mapa[2][3].simbolo != Parede.simbolo {
+			t.Errorf("%s: parede com o fantasma virou %q", forma, jogo.Mapa[2][3].simbolo)
+		}
+	}
+}
+
+// O centro de uma bomba sobre a parede (de um salvamento editado) segue a
+// mesma regra das demais células: a parede não é atingida
+func TestExplosaoCentroNaParede(t *testing.T) {
+	jogo := testeCarregarMapa(t, "testdata/fantasma.txt")
+	for _, forma := range FormasBomba {
+		for _, p := range explosaoCelulas(&jogo, 3, 2, forma, 2) {
+			if !explosaoCelulaLivre(&jogo, p.X, p.Y) {
+				t.Errorf("%s: explosão ocupou a parede %q em %v", forma, jogo.Mapa[p.Y][p.X].simbolo, p)
+			}
+		}
+	}
+}
+
+// Dá a vez à goroutine das explosões e retorna o dano enviado pelo canal
+// chanVida (0 se nenhum) e a resposta dela
+func testeAgirExplosoes(jogo *Jogo) (int, Comando) {
+	vez, sair := make(chan Jogo), make(chan struct{})
+	acoes, chanVida := make(chan Comando), make(chan int)
+	defer close(sair)
+	go explosaoExecutar(vez, sair, acoes, chanVida)
+
+	vez <- jogoCopiar(jogo)
+	dano := 0
+	for {
+		select {
+		case v := <-chanVida:
+			dano += v
+		case cmd := <-acoes:
+			return dano, cmd
+		}
+	}
+}
+
+// Explosões e chamas só ferem o personagem quando o mapa tem fogo amigo; o
+// pisão do chefe fere sempre. O dano das explosões chega pelo canal chanVida
+func TestFogoAmigo(t *testing.T) {
+	for _, fogoAmigo := range []bool{true, false} {
+		jogo := testeCarregarMapa(t, "testdata/fantasma.txt")
+		jogo.Cabecalho.FogoAmigo = fogoAmigo
+		p := jogo.Entidades[0]
+
+		jogo.Explosoes = []Explosao{{X: p.X, Y: p.Y, TempoVida: jogo.Tempo, Ativa: true}}
+		if dano, _ := testeAgirExplosoes(&jogo); (dano < 0) != fogoAmigo {
+			t.Errorf("fogo amigo %v: dano da explosão = %d", fogoAmigo, dano)
+		}
+
+		jogo.Explosoes[0].Pisao = true
+		dano, cmd := testeAgirExplosoes(&jogo)
+		if dano != -1 {
+			t.Errorf("fogo amigo %v: dano do pisão = %d, esperado -1", fogoAmigo, dano)
+		}
+		jogoAlterarVida(&jogo, dano)
+		cmd.executar(&jogo)
+		if jogo.Vida != 2 || jogo.StatusMsg != "Você foi atingido pelo pisão do chefe!" {
+			t.Errorf("fogo amigo %v: após o pisão vida = %d, mensagem %q", fogoAmigo, jogo.Vida, jogo.StatusMsg)
+		}
+
+		jogo.Chamas = []Chama{{X: p.X, Y: p.Y, Inicio: jogo.Tempo}}
+		if _, queimou := fogoAgir(&jogo); queimou != fogoAmigo {
+			t.Errorf("fogo amigo %v: personagem nas chamas queimado = %v", fogoAmigo, queimou)
+		}
+	}
+}
+
+// Coloca uma bomba já vencida em (x, y) e a explode pelo passo das bombas
+// Retorna a posição das bombas que explodiram, na ordem
+func testeExplodirBomba(jogo *Jogo, x, y int) []Ponto {
+	inicio := jogo.Tempo.Add(-DuracaoBomba)
+	jogo.Bombas = append(jogo.Bombas, Bomba{X: x, Y: y, TempoVida: inicio, Ativa: true, Forma: FormaLosango})
+	return jogoAtualizarBombas(jogo)
+}
+
+// O aviso de um inimigo resistente atingido não é escondido pelo da bomba
+func TestMensagemTanqueAtingido(t *testing.T) {
+	jogo := testeCarregarMapa(t, "testdata/fantasma.txt")
+	tanque := &jogo.Entidades[jogoBuscarEntidade(&jogo, testeBuscarTipo(t, &jogo, TipoFantasma))]
+	tanque.Tipo = TipoTanque
+	tanque.Vida = arquetipoDe(*tanque).Vida
+
+	testeExplodirBomba(&jogo, 3, 1)
+	if esperada := "Tanque atingido! Resta 2 de vida"; jogo.StatusMsg != esperada {
+		t.Errorf("mensagem = %q, esperada %q", jogo.StatusMsg, esperada)
+	}
+
+	testeExplodirBomba(&jogo, 1, 2)
+	if esperada := "BOOM! Bomba explodiu!"; jogo.StatusMsg != esperada {
+		t.Errorf("explosão sem atingir ninguém: mensagem = %q, esperada %q", jogo.StatusMsg, esperada)
+	}
+}
+
+// A explosão de uma bomba detona duas outras, e uma delas uma quarta. A bomba
+// alcançada pelas duas primeiras explode uma vez só, e as bombas explodem na
+// ordem em que foram alcançadas
+func TestReacaoEmCadeia(t *testing.T) {
+	jogo := testeCarregarMapa(t, "testdata/cadeia.txt")
+	for _, p := range []Ponto{{3, 2}, {5, 1}, {7, 1}, {9, 2}} {
+		jogo.Bombas = append(jogo.Bombas, Bomba{X: p.X, Y: p.Y, TempoVida: jogo.Tempo, Ativa: true, Forma: FormaLosango})
+	}
+
+	explodidas := testeExplodirBomba(&jogo, 3, 1)
+	if esperadas := []Ponto{{3, 1}, {3, 2}, {5, 1}, {7, 1}}; !slices.Equal(explodidas, esperadas) {
+		t.Errorf("bombas explodidas %v, esperado %v", explodidas, esperadas)
+	}
+	if jogo.Cadeia != 4 || jogo.StatusMsg != "BOOM! Reação em cadeia de 4 bombas!" {
+		t.Errorf("cadeia %d, mensagem %q", jogo.Cadeia, jogo.StatusMsg)
+	}
+	if len(jogo.Bombas) != 1 || jogo.Bombas[0].X != 9 {
+		t.Errorf("bombas restantes %v, esperada só a de (9, 2), fora do alcance", jogo.Bombas)
+	}
+}
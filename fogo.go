@@ -0,0 +1,106 @@
+// fogo.go - Paredes frágeis e fogo na vegetação causados pelas explosões
+package main
+
+import "time"
+
+// Tempos do fogo
+const (
+	IntervaloFogo   = 250 * time.Millisecond // tempo entre duas vezes do fogo agir
+	PropagacaoChama = 500 * time.Millisecond // idade com que a chama passa para a vegetação vizinha
+	DuracaoChama    = 2 * time.Second        // tempo até a chama se apagar
+)
+
+// Chama é uma célula de vegetação pegando fogo
+type Chama struct {
+	X, Y   int       // posição no mapa
+	Inicio time.Time // quando a célula pegou fogo
+}
+
+// Elementos do terreno destrutível
+var (
+	ParedeFragil = Elemento{'▒', CorParede, CorPadrao, true}     // parede que as explosões derrubam
+	FogoElem     = Elemento{'▲', CorAmarelo, CorVermelho, false} // vegetação em chamas
+)
+
+// ============================================================================
+// MÓDULO DE TERRENO DESTRUTÍVEL
+// ============================================================================
+
+// Derruba a parede frágil atingida por uma explosão na posição (x, y), se houver
+func jogoVerificarParedeNaExplosao(jogo *Jogo, x, y int) {
+	if jogo.Mapa[y][x].simbolo == ParedeFragil.simbolo {
+		jogoTrocarCelula(jogo, x, y, Vazio)
+	}
+}
+
+// Põe fogo na vegetação da posição (x, y), se houver
+// A célula vira fogo até a chama se apagar
+func jogoAcenderFogo(jogo *Jogo, x, y int) {
+	if jogo.Mapa[y][x].simbolo != Vegetacao.simbolo {
+		return
+	}
+	jogoTrocarCelula(jogo, x, y, FogoElem)
+	jogo.Chamas = append(jogo.Chamas, Chama{X: x, Y: y, Inicio: jogo.Tempo})
+}
+
+// Apaga a chama da posição (x, y); a vegetação queimada vira Vazio
+func jogoApagarChama(jogo *Jogo, x, y int) {
+	for i, c := range jogo.Chamas {
+		if c.X == x && c.Y == y {
+			jogo.Chamas = append(jogo.Chamas[:i], jogo.Chamas[i+1:]...)
+			jogoTrocarCelula(jogo, x, y, Vazio)
+			return
+		}
+	}
+}
+
+// ============================================================================
+// MÓDULO DE PROCESSAMENTO DE AÇÕES
+// ============================================================================
+
+// Goroutine do fogo: espalha, queima e apaga todas as chamas do mapa a cada
+// IntervaloFogo e envia dano pelo canal chanVida se o personagem queimar
+func fogoExecutar(vez <-chan Jogo, sair <-chan struct{}, acoes chan<- Comando, chanVida chan<- int) {
+	simulacaoAgirNaVez(vez, sair, acoes, func(quadro *Jogo) Comando {
+		cmd, queimou := fogoAgir(quadro)
+		if queimou {
+			chanVida <- -1 // O dano respeita o intervalo entre danos
+		}
+		return cmd
+	})
+}
+
+// Decide o que acontece com cada chama nesta vez
+// Chamas com mais de DuracaoChama se apagam; as demais queimam o que está
+// na sua célula e, depois de PropagacaoChama, passam para a vegetação
+// vizinha. Retorna o comando e se o personagem está sendo queimado (só
+// quando o mapa tem fogo amigo)
+func fogoAgir(jogo *Jogo) (ComandoFogo, bool) {
+	var cmd ComandoFogo
+	p := jogo.Entidades[0]
+	queimou := false
+
+	for _, c := range jogo.Chamas {
+		celula := Ponto{c.X, c.Y}
+		idade := jogo.Tempo.Sub(c.Inicio)
+		if idade >= DuracaoChama {
+			cmd.Apagar = append(cmd.Apagar, celula)
+			continue
+		}
+
+		cmd.Queimar = append(cmd.Queimar, celula)
+		if p.X == c.X && p.Y == c.Y && jogo.Vida > 0 && jogo.Cabecalho.FogoAmigo {
+			queimou = true
+		}
+
+		if idade >= PropagacaoChama {
+			for _, d := range direcoesCaminho {
+				x, y := c.X+d.X, c.Y+d.Y
+				if y >= 0 && y < len(jogo.Mapa) && x >= 0 && x < len(jogo.Mapa[y]) && jogo.Mapa[y][x].simbolo == Vegetacao.simbolo {
+					cmd.Acender = append(cmd.Acender, Ponto{x, y})
+				}
+			}
+		}
+	}
+	return cmd, queimou
+}
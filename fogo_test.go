@@ -0,0 +1,61 @@
+// fogo_test.go - Testes do terreno destrutível e do fogo na vegetação
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// Retorna as células em chamas, na ordem em que pegaram fogo
+func testeCelulasEmChamas(jogo *Jogo) []Ponto {
+	var celulas []Ponto
+	for _, c := range jogo.Chamas {
+		celulas = append(celulas, Ponto{c.X, c.Y})
+	}
+	return celulas
+}
+
+// A explosão derruba a parede frágil e acende a vegetação; a cada
+// PropagacaoChama o fogo passa para a vegetação vizinha e, depois de
+// DuracaoChama, cada chama se apaga deixando a célula vazia
+func TestFogoEspalhaNaVegetacao(t *testing.T) {
+	jogo := testeCarregarMapa(t, "testdata/fogo.txt")
+	relogio := relogioSimuladoNovo()
+	jogo.Tempo = relogio.Agora()
+
+	jogoExplodirBomba(&jogo, Bomba{X: 2, Y: 1, Ativa: true, Forma: FormaLosango})
+	if jogo.Mapa[2][2].simbolo != Vazio.simbolo {
+		t.Errorf("parede frágil atingida virou %q", jogo.Mapa[2][2].simbolo)
+	}
+	if chamas := testeCelulasEmChamas(&jogo); !slices.Equal(chamas, []Ponto{{3, 1}}) {
+		t.Fatalf("chamas depois da explosão %v, esperada só (3, 1)", chamas)
+	}
+
+	// Células em chamas depois de cada vez do fogo, de IntervaloFogo em IntervaloFogo
+	esperadas := map[time.Duration][]Ponto{
+		250 * time.Millisecond:  {{3, 1}},
+		500 * time.Millisecond:  {{3, 1}, {4, 1}},
+		1000 * time.Millisecond: {{3, 1}, {4, 1}, {4, 2}, {5, 1}},
+		1500 * time.Millisecond: {{3, 1}, {4, 1}, {4, 2}, {5, 1}, {5, 2}},
+		2000 * time.Millisecond: {{4, 1}, {4, 2}, {5, 1}, {5, 2}},
+		3000 * time.Millisecond: {{5, 2}},
+		3500 * time.Millisecond: nil,
+	}
+	for decorrido := IntervaloFogo; decorrido <= 4*time.Second; decorrido += IntervaloFogo {
+		relogio.Avancar(IntervaloFogo)
+		jogo.Tempo = relogio.Agora()
+		cmd, _ := fogoAgir(&jogo)
+		cmd.executar(&jogo)
+
+		if chamas, ok := esperadas[decorrido]; ok && !slices.Equal(testeCelulasEmChamas(&jogo), chamas) {
+			t.Errorf("depois de %v: chamas %v, esperadas %v", decorrido, testeCelulasEmChamas(&jogo), chamas)
+		}
+	}
+
+	for _, p := range []Ponto{{3, 1}, {4, 1}, {5, 1}, {4, 2}, {5, 2}} {
+		if jogo.Mapa[p.Y][p.X].simbolo != Vazio.simbolo {
+			t.Errorf("vegetação queimada em %v virou %q", p, jogo.Mapa[p.Y][p.X].simbolo)
+		}
+	}
+}
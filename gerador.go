@@ -0,0 +1,397 @@
+// gerador.go - Geração procedural de mapas (labirintos, masmorras e cavernas)
+package main
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"strings"
+)
+
+// Algoritmos de geração disponíveis
+const (
+	AlgoritmoLabirinto = "labirinto" // labirinto perfeito (backtracking recursivo)
+	AlgoritmoMasmorra  = "masmorra"  // salas ligadas por corredores
+	AlgoritmoCavernas  = "cavernas"  // cavernas por autômato celular
+)
+
+// Lista dos algoritmos na ordem em que são sorteados para um nível aleatório
+var AlgoritmosGerador = []string{AlgoritmoLabirinto, AlgoritmoMasmorra, AlgoritmoCavernas}
+
+// Tamanho mínimo de um mapa gerado
+const (
+	LarguraMinimaGerador = 7
+	AlturaMinimaGerador  = 5
+)
+
+// Tamanho mínimo das cavernas: em mapas menores a suavização do autômato
+// celular transforma todo o interior em parede
+const (
+	LarguraMinimaCavernas = 9
+	AlturaMinimaCavernas  = 7
+)
+
+// Cavernas geradas até uma ter espaço para o personagem, os inimigos e as curas
+const TentativasCavernas = 50
+
+// ConfigGerador reúne os parâmetros de geração de um mapa
+type ConfigGerador struct {
+	Algoritmo       string  // AlgoritmoLabirinto, AlgoritmoMasmorra ou AlgoritmoCavernas
+	Largura, Altura int     // dimensões do mapa, incluindo as bordas
+	Inimigos        int     // quantidade de inimigos
+	Curas           int     // quantidade de curas
+	Vegetacao       float64 // fração das células livres coberta de vegetação (0 a 1)
+	Semente         uint64  // mesma semente e parâmetros geram o mesmo mapa
+}
+
+// Retorna os parâmetros usados quando nenhum é informado
+func geradorConfigPadrao() ConfigGerador {
+	return ConfigGerador{
+		Algoritmo: AlgoritmoMasmorra,
+		Largura:   61,
+		Altura:    21,
+		Inimigos:  4,
+		Curas:     1,
+		Vegetacao: 0.1,
+	}
+}
+
+// ============================================================================
+// MÓDULO DE GERAÇÃO DE MAPAS
+// ============================================================================
+
+// Gera um mapa no formato de símbolos de mapa.txt, uma string por linha
+// Personagem, inimigos e curas são colocados apenas na região conectada ao
+// personagem, de modo que ele sempre alcança todos os inimigos e curas
+func geradorGerar(config ConfigGerador) ([]string, error) {
+	if config.Largura < LarguraMinimaGerador || config.Altura < AlturaMinimaGerador {
+		return nil, fmt.Errorf("tamanho mínimo do mapa é %dx%d", LarguraMinimaGerador, AlturaMinimaGerador)
+	}
+	if config.Inimigos < 0 || config.Curas < 0 {
+		return nil, fmt.Errorf("quantidades de inimigos e curas não podem ser negativas")
+	}
+	if config.Vegetacao < 0 || config.Vegetacao > 1 {
+		return nil, fmt.Errorf("densidade de vegetação deve estar entre 0 e 1")
+	}
+
+	rng := rand.New(rand.NewPCG(config.Semente, 0))
+	grade := geradorGradeCheia(config.Largura, config.Altura)
+
+	switch config.Algoritmo {
+	case AlgoritmoLabirinto:
+		geradorLabirinto(grade, rng)
+	case AlgoritmoMasmorra:
+		geradorMasmorra(grade, rng)
+	case AlgoritmoCavernas:
+		if config.Largura < LarguraMinimaCavernas || config.Altura < AlturaMinimaCavernas {
+			return nil, fmt.Errorf("tamanho mínimo do mapa de cavernas é %dx%d", LarguraMinimaCavernas, AlturaMinimaCavernas)
+		}
+		grade, rng = geradorCavernasComEspaco(config)
+	default:
+		return nil, fmt.Errorf("algoritmo desconhecido %q (use %s)", config.Algoritmo, strings.Join(AlgoritmosGerador, ", "))
+	}
+
+	if err := geradorPovoar(grade, config, rng); err != nil {
+		return nil, err
+	}
+
+	linhas := make([]string, len(grade))
+	for y, linha := range grade {
+		linhas[y] = string(linha)
+	}
+	return linhas, nil
+}
+
+// Cria uma grade toda preenchida com paredes
+func geradorGradeCheia(largura, altura int) [][]rune {
+	grade := make([][]rune, altura)
+	for y := range grade {
+		grade[y] = make([]rune, largura)
+		for x := range grade[y] {
+			grade[y][x] = Parede.simbolo
+		}
+	}
+	return grade
+}
+
+// Escava um labirinto perfeito com backtracking recursivo
+// As células ficam nas coordenadas ímpares e as paredes entre elas são
+// derrubadas ao visitar um vizinho ainda não visitado. Usa uma pilha
+// explícita para não depender da profundidade de recursão
+func geradorLabirinto(grade [][]rune, rng *rand.Rand) {
+	altura, largura := len(grade), len(grade[0])
+	grade[1][1] = Vazio.simbolo
+	pilha := [][2]int{{1, 1}}
+
+	for len(pilha) > 0 {
+		x, y := pilha[len(pilha)-1][0], pilha[len(pilha)-1][1]
+
+		// Vizinhos a duas células de distância ainda não escavados
+		var vizinhos [][2]int
+		for _, d := range [][2]int{{0, -2}, {-2, 0}, {0, 2}, {2, 0}} {
+			nx, ny := x+d[0], y+d[1]
+			if nx > 0 && nx < largura-1 && ny > 0 && ny < altura-1 && grade[ny][nx] == Parede.simbolo {
+				vizinhos = append(vizinhos, [2]int{nx, ny})
+			}
+		}
+
+		if len(vizinhos) == 0 {
+			pilha = pilha[:len(pilha)-1] // Beco sem saída: volta
+			continue
+		}
+
+		v := vizinhos[rng.IntN(len(vizinhos))]
+		grade[(y+v[1])/2][(x+v[0])/2] = Vazio.simbolo // Derruba a parede entre as células
+		grade[v[1]][v[0]] = Vazio.simbolo
+		pilha = append(pilha, v)
+	}
+}
+
+// Sala retangular de uma masmorra (interior, sem as paredes)
+type salaGerador struct {
+	x, y, largura, altura int
+}
+
+// Cria salas que não se sobrepõem e liga cada uma à anterior por um
+// corredor em L, o que mantém todas conectadas
+func geradorMasmorra(grade [][]rune, rng *rand.Rand) {
+	altura, largura := len(grade), len(grade[0])
+	var salas []salaGerador
+
+	for tentativa := 0; tentativa < 200 && len(salas) < 12; tentativa++ {
+		s := salaGerador{
+			largura: 3 + rng.IntN(min(8, largura-4)),
+			altura:  2 + rng.IntN(min(4, altura-4)),
+		}
+		s.x = 1 + rng.IntN(largura-s.largura-1)
+		s.y = 1 + rng.IntN(altura-s.altura-1)
+
+		sobrepoe := false
+		for _, outra := range salas {
+			// Mantém ao menos uma parede entre duas salas
+			if s.x <= outra.x+outra.largura && outra.x <= s.x+s.largura &&
+				s.y <= outra.y+outra.altura && outra.y <= s.y+s.altura {
+				sobrepoe = true
+				break
+			}
+		}
+		if sobrepoe {
+			continue
+		}
+
+		for y := s.y; y < s.y+s.altura; y++ {
+			for x := s.x; x < s.x+s.largura; x++ {
+				grade[y][x] = Vazio.simbolo
+			}
+		}
+		if len(salas) > 0 {
+			geradorCorredor(grade, salas[len(salas)-1], s, rng)
+		}
+		salas = append(salas, s)
+	}
+}
+
+// Escava um corredor em L entre os centros de duas salas
+func geradorCorredor(grade [][]rune, a, b salaGerador, rng *rand.Rand) {
+	ax, ay := a.x+a.largura/2, a.y+a.altura/2
+	bx, by := b.x+b.largura/2, b.y+b.altura/2
+
+	// Sorteia se o corredor começa na horizontal ou na vertical
+	cx, cy := bx, ay
+	if rng.IntN(2) == 0 {
+		cx, cy = ax, by
+	}
+	geradorEscavarReta(grade, ax, ay, cx, cy)
+	geradorEscavarReta(grade, cx, cy, bx, by)
+}
+
+// Escava uma linha horizontal ou vertical entre dois pontos
+func geradorEscavarReta(grade [][]rune, x1, y1, x2, y2 int) {
+	for x := min(x1, x2); x <= max(x1, x2); x++ {
+		grade[y1][x] = Vazio.simbolo
+	}
+	for y := min(y1, y2); y <= max(y1, y2); y++ {
+		grade[y][x1] = Vazio.simbolo
+	}
+}
+
+// Gera cavernas com um autômato celular
+// O interior começa com paredes aleatórias e é suavizado algumas vezes: uma
+// célula vira parede quando a maioria dos vizinhos é parede. Depois, apenas a
+// maior caverna é mantida, para que todo espaço livre seja alcançável
+func geradorCavernas(grade [][]rune, rng *rand.Rand) {
+	altura, largura := len(grade), len(grade[0])
+	for y := 1; y < altura-1; y++ {
+		for x := 1; x < largura-1; x++ {
+			if rng.Float64() >= 0.45 {
+				grade[y][x] = Vazio.simbolo
+			}
+		}
+	}
+
+	for passo := 0; passo < 5; passo++ {
+		proxima := geradorGradeCheia(largura, altura)
+		for y := 1; y < altura-1; y++ {
+			for x := 1; x < largura-1; x++ {
+				paredes := 0
+				for dy := -1; dy <= 1; dy++ {
+					for dx := -1; dx <= 1; dx++ {
+						if (dx != 0 || dy != 0) && grade[y+dy][x+dx] == Parede.simbolo {
+							paredes++
+						}
+					}
+				}
+				switch {
+				case paredes > 4:
+					proxima[y][x] = Parede.simbolo
+				case paredes < 4:
+					proxima[y][x] = Vazio.simbolo
+				default:
+					proxima[y][x] = grade[y][x]
+				}
+			}
+		}
+		copy(grade, proxima)
+	}
+
+	// Mantém apenas a maior região livre
+	var maior [][2]int
+	visitado := make(map[[2]int]bool)
+	for y := range grade {
+		for x := range grade[y] {
+			if grade[y][x] == Vazio.simbolo && !visitado[[2]int{x, y}] {
+				if regiao := geradorRegiao(grade, x, y, visitado); len(regiao) > len(maior) {
+					maior = regiao
+				}
+			}
+		}
+	}
+	naMaior := make(map[[2]int]bool, len(maior))
+	for _, c := range maior {
+		naMaior[c] = true
+	}
+	for y := range grade {
+		for x := range grade[y] {
+			if grade[y][x] == Vazio.simbolo && !naMaior[[2]int{x, y}] {
+				grade[y][x] = Parede.simbolo
+			}
+		}
+	}
+}
+
+// Gera cavernas até que a maior delas tenha células para o personagem, os
+// inimigos e as curas. A primeira tentativa usa a própria semente e as
+// seguintes, geradores derivados dela, então a mesma semente ainda gera o
+// mesmo mapa. Retorna a grade e o gerador da última tentativa
+func geradorCavernasComEspaco(config ConfigGerador) ([][]rune, *rand.Rand) {
+	var grade [][]rune
+	var rng *rand.Rand
+	for tentativa := range TentativasCavernas {
+		rng = rand.New(rand.NewPCG(config.Semente, uint64(tentativa)))
+		grade = geradorGradeCheia(config.Largura, config.Altura)
+		geradorCavernas(grade, rng)
+		if len(geradorLivres(grade)) >= 1+config.Inimigos+config.Curas {
+			break
+		}
+	}
+	return grade, rng
+}
+
+// Retorna as células livres da grade, linha a linha
+func geradorLivres(grade [][]rune) [][2]int {
+	var livres [][2]int
+	for y := range grade {
+		for x := range grade[y] {
+			if grade[y][x] == Vazio.simbolo {
+				livres = append(livres, [2]int{x, y})
+			}
+		}
+	}
+	return livres
+}
+
+// Retorna as células livres conectadas a (x, y), andando nas quatro direções
+func geradorRegiao(grade [][]rune, x, y int, visitado map[[2]int]bool) [][2]int {
+	regiao := [][2]int{{x, y}}
+	visitado[[2]int{x, y}] = true
+	for i := 0; i < len(regiao); i++ {
+		c := regiao[i]
+		for _, d := range [][2]int{{0, -1}, {-1, 0}, {0, 1}, {1, 0}} {
+			v := [2]int{c[0] + d[0], c[1] + d[1]}
+			if grade[v[1]][v[0]] == Vazio.simbolo && !visitado[v] {
+				visitado[v] = true
+				regiao = append(regiao, v)
+			}
+		}
+	}
+	return regiao
+}
+
+// Coloca personagem, inimigos, curas e vegetação nas células livres
+// Todas as células livres pertencem à mesma região, então tudo o que for
+// colocado é alcançável pelo personagem
+func geradorPovoar(grade [][]rune, config ConfigGerador, rng *rand.Rand) error {
+	livres := geradorLivres(grade)
+	if len(livres) < 1+config.Inimigos+config.Curas {
+		return fmt.Errorf("o mapa gerado tem %d células livres, insuficientes para 1 personagem, %d inimigos e %d curas",
+			len(livres), config.Inimigos, config.Curas)
+	}
+	rng.Shuffle(len(livres), func(i, j int) { livres[i], livres[j] = livres[j], livres[i] })
+
+	p := livres[0]
+	grade[p[1]][p[0]] = Personagem.simbolo
+	livres = livres[1:]
+
+	// Inimigos começam longe do personagem: são sorteados na metade das
+	// células livres mais distante dele
+	distancia := func(c [2]int) int { return abs(c[0]-p[0]) + abs(c[1]-p[1]) }
+	sort.SliceStable(livres, func(i, j int) bool { return distancia(livres[i]) > distancia(livres[j]) })
+	distantes := livres[:max(config.Inimigos, len(livres)/2)]
+	rng.Shuffle(len(distantes), func(i, j int) { distantes[i], distantes[j] = distantes[j], distantes[i] })
+	for _, c := range distantes[:config.Inimigos] {
+		grade[c[1]][c[0]] = Inimigo.simbolo
+	}
+
+	// Curas e vegetação ocupam as demais células, em ordem aleatória
+	rng.Shuffle(len(livres), func(i, j int) { livres[i], livres[j] = livres[j], livres[i] })
+	for _, c := range livres {
+		if grade[c[1]][c[0]] != Vazio.simbolo {
+			continue
+		}
+		switch {
+		case config.Curas > 0:
+			grade[c[1]][c[0]] = Cura.simbolo
+			config.Curas--
+		case rng.Float64() < config.Vegetacao:
+			grade[c[1]][c[0]] = Vegetacao.simbolo
+		}
+	}
+	return nil
+}
+
+// ============================================================================
+// MÓDULO DE NÍVEL ALEATÓRIO
+// ============================================================================
+
+// Monta um novo jogo em um mapa gerado, continuando a partida anterior
+// (veja jogoContinuar)
+func jogoNovoNivelAleatorio(anterior *Jogo, config ConfigGerador) (Jogo, error) {
+	linhas, err := geradorGerar(config)
+	if err != nil {
+		return Jogo{}, err
+	}
+
+	nome := fmt.Sprintf("%s aleatório (semente %d)", config.Algoritmo, config.Semente)
+	arq, err := mapaInterpretar(nome, strings.NewReader(strings.Join(linhas, "\n")))
+	if err != nil {
+		return Jogo{}, err
+	}
+	if err := mapaValidar(nome, arq); err != nil {
+		return Jogo{}, err
+	}
+
+	jogo := jogoContinuar(anterior)
+	jogoMontarMapa(arq, &jogo)
+	jogo.StatusMsg = "Novo nível: " + nome
+	return jogo, nil
+}
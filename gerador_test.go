@@ -0,0 +1,59 @@
+// gerador_test.go - Testes da geração procedural de mapas
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Sementes testadas para cada algoritmo e tamanho
+const SementesTeste = 50
+
+// Do tamanho mínimo de cada algoritmo ao padrão, todo mapa gerado com a
+// quantidade padrão de inimigos e curas passa na validação (o personagem
+// alcança todos eles)
+func TestGeradorMapasValidos(t *testing.T) {
+	tamanhos := map[string][][2]int{
+		AlgoritmoLabirinto: {{LarguraMinimaGerador, AlturaMinimaGerador}, {9, 7}, {21, 7}, {61, 21}},
+		AlgoritmoMasmorra:  {{LarguraMinimaGerador, AlturaMinimaGerador}, {9, 7}, {21, 7}, {61, 21}},
+		AlgoritmoCavernas:  {{LarguraMinimaCavernas, AlturaMinimaCavernas}, {9, 9}, {11, 7}, {11, 9}, {21, 7}, {61, 21}},
+	}
+
+	for _, algoritmo := range AlgoritmosGerador {
+		for _, tamanho := range tamanhos[algoritmo] {
+			for semente := uint64(1); semente <= SementesTeste; semente++ {
+				config := geradorConfigPadrao()
+				config.Algoritmo = algoritmo
+				config.Largura, config.Altura = tamanho[0], tamanho[1]
+				config.Semente = semente
+
+				nome := fmt.Sprintf("%s %dx%d semente %d", algoritmo, tamanho[0], tamanho[1], semente)
+				linhas, err := geradorGerar(config)
+				if err != nil {
+					t.Errorf("%s: %v", nome, err)
+					continue
+				}
+				arq, err := mapaInterpretar(nome, strings.NewReader(strings.Join(linhas, "\n")))
+				if err == nil {
+					err = mapaValidar(nome, arq)
+				}
+				if err != nil {
+					t.Errorf("%s: mapa gerado inválido: %v", nome, err)
+				}
+			}
+		}
+	}
+}
+
+// Cavernas abaixo do tamanho mínimo são recusadas com uma mensagem clara
+func TestGeradorCavernasTamanhoMinimo(t *testing.T) {
+	config := geradorConfigPadrao()
+	config.Algoritmo = AlgoritmoCavernas
+	config.Largura, config.Altura = LarguraMinimaGerador, AlturaMinimaGerador
+	config.Semente = 1
+
+	if _, err := geradorGerar(config); err == nil || !strings.Contains(err.Error(), "cavernas") {
+		t.Errorf("caverna %dx%d: erro = %v, esperado o tamanho mínimo das cavernas", config.Largura, config.Altura, err)
+	}
+}
@@ -2,8 +2,9 @@ module jogo
 
 go 1.24.5
 
+require github.com/nsf/termbox-go v1.1.1
+
 require (
 	github.com/mattn/go-runewidth v0.0.16 // indirect
-	github.com/nsf/termbox-go v1.1.1 // indirect
 	github.com/rivo/uniseg v0.4.7 // indirect
 )
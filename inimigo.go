@@ -11,6 +11,81 @@ import (
 	"time"
 )
 
+// Intervalo padrão entre duas ações de um inimigo (velocidade do inimigo)
+// Um mapa pode definir outro valor em seu cabeçalho
+const IntervaloInimigo = 500 * time.Millisecond
+
+// Distância máxima (Manhattan) em que um inimigo comum enxerga o personagem
+// Cada tipo de inimigo tem seu alcance (veja arquetiposInimigo). Escondido na
+// vegetação, o personagem só é visto na metade dessa distância
+const AlcanceDeteccao = 10
+
+// Tempo que um inimigo procura o personagem na última posição conhecida
+// antes de desistir e voltar à patrulha
+const TempoProcura = 5 * time.Second
+
+// Distância máxima (Manhattan) em que um inimigo ouve uma explosão
+const AlcanceRuido = 12
+
+// EstadoInimigo é um estado da máquina de estados de um inimigo
+type EstadoInimigo int
+
+const (
+	EstadoPatrulha    EstadoInimigo = iota // segue sua rota ou anda ao acaso
+	EstadoAlerta                           // acabou de notar algo e para por uma vez
+	EstadoPerseguindo                      // enxerga o personagem e vai até ele
+	EstadoProcurando                       // perdeu o contato e procura na última posição conhecida
+	EstadoRetornando                       // desistiu da procura e volta ao ponto onde patrulhava
+)
+
+func (e EstadoInimigo) String() string {
+	switch e {
+	case EstadoPatrulha:
+		return "Patrulhando"
+	case EstadoAlerta:
+		return "Alerta!"
+	case EstadoPerseguindo:
+		return "Perseguindo"
+	case EstadoProcurando:
+		return "Procurando"
+	case EstadoRetornando:
+		return "Retornando"
+	}
+	return "Desconhecido"
+}
+
+// Tipos de estímulo que provocam as transições de estado
+// Todos chegam pelo canal de estímulos do inimigo, enviados pela simulação
+const (
+	EstimuloAvistou = "avistou" // personagem à vista
+	EstimuloPerdeu  = "perdeu"  // personagem saiu de vista
+	EstimuloRuido   = "ruido"   // explosão ouvida
+	EstimuloExpirou = "expirou" // tempo de procura esgotado
+	EstimuloChegou  = "chegou"  // inimigo voltou ao ponto onde patrulhava
+)
+
+// EstimuloInimigo é uma mensagem que pode mudar o estado de um inimigo
+type EstimuloInimigo struct {
+	Tipo  string
+	Ponto Ponto // posição do personagem visto ou da explosão ouvida
+}
+
+// MenteInimigo é a memória de um inimigo entre as vezes de agir
+// Fica na sua Entidade, para ser salva com o jogo: a goroutine a lê do quadro
+// e devolve a memória atualizada no comando
+type MenteInimigo struct {
+	Estado  EstadoInimigo
+	Alvo    Ponto          // última posição conhecida do personagem (ou do ruído)
+	Posto   Ponto          // onde o inimigo estava quando deixou a patrulha
+	Prazo   time.Time      // fim da procura
+	Caminho CaminhoInimigo // caminho até o alvo guardado entre as vezes
+	Etapa   int            // índice do próximo ponto da rota de patrulha
+	Volta   bool           // rota vaivem sendo percorrida do fim para o começo
+
+	ProximoDisparo time.Time // quando a arma estará recarregada (atiradores)
+	ProximoAtaque  time.Time // quando o ataque especial estará recarregado (chefe)
+}
+
 // ============================================================================
 // MÓDULO DE DETECÇÃO E DISTÂNCIA
 // ============================================================================
@@ -29,21 +104,82 @@ func inimigoDetectaPersonagem(x1, y1, x2, y2 int) int {
 	return dx + dy
 }
 
+// Verifica se há linha de visão entre duas células
+// Percorre a reta entre elas com o algoritmo de Bresenham: qualquer elemento
+// tangível do mapa (parede) no meio do caminho bloqueia a visão. As células
+// das pontas não são verificadas
+func inimigoLinhaDeVisao(jogo *Jogo, x1, y1, x2, y2 int) bool {
+	dx, dy := abs(x2-x1), -abs(y2-y1)
+	sx, sy := 1, 1
+	if x1 > x2 {
+		sx = -1
+	}
+	if y1 > y2 {
+		sy = -1
+	}
+	erro := dx + dy
+
+	x, y := x1, y1
+	for {
+		if (x != x1 || y != y1) && (x != x2 || y != y2) {
+			if y < 0 || y >= len(jogo.Mapa) || x < 0 || x >= len(jogo.Mapa[y]) || jogo.Mapa[y][x].tangivel {
+				return false
+			}
+		}
+		if x == x2 && y == y2 {
+			return true
+		}
+
+		e2 := 2 * erro
+		if e2 >= dy {
+			erro += dy
+			x += sx
+		}
+		if e2 <= dx {
+			erro += dx
+			y += sy
+		}
+	}
+}
+
+// Verifica se o inimigo enxerga o personagem
+// O personagem precisa estar dentro do alcance de detecção do tipo do
+// inimigo, reduzido à metade quando ele está sobre vegetação, e em linha de
+// visão. Retorna também a distância até ele
+func inimigoVePersonagem(jogo *Jogo, idx int) (bool, int) {
+	ent, p := jogo.Entidades[idx], jogo.Entidades[0]
+	dist := inimigoDetectaPersonagem(ent.X, ent.Y, p.X, p.Y)
+
+	alcance := arquetipoDe(ent).Alcance
+	if p.UltimoVisitado.simbolo == Vegetacao.simbolo {
+		alcance /= 2 // Vegetação esconde o personagem
+	}
+	if dist > alcance {
+		return false, dist
+	}
+	return inimigoLinhaDeVisao(jogo, ent.X, ent.Y, p.X, p.Y), dist
+}
+
 // ============================================================================
 // MÓDULO DE MOVIMENTAÇÃO E COMPORTAMENTO
 // ============================================================================
 
-// Move inimigo aleatoriamente em uma das 4 direções
-func inimigoMover(jogo *Jogo, idx int) {
-	// Verifica se o índice ainda é válido
-	if idx >= len(jogo.Entidades) {
-		return
+// Calcula o passo de patrulha do inimigo
+// Um inimigo com rota segue os seus pontos; os demais andam ao acaso
+func inimigoMover(jogo *Jogo, idx int, rng *rand.Rand, mente *MenteInimigo) (int, int) {
+	if jogo.Entidades[idx].Rota != nil {
+		return inimigoSeguirRota(jogo, idx, mente)
 	}
+	return inimigoMoverAleatorio(jogo, idx, rng)
+}
 
+// Escolhe uma direção aleatória entre as 4 possíveis
+// Retorna o deslocamento (0, 0) se o movimento não for válido
+func inimigoMoverAleatorio(jogo *Jogo, idx int, rng *rand.Rand) (int, int) {
 	dx, dy := 0, 0
 
 	// Escolhe direção aleatória (0=cima, 1=esquerda, 2=baixo, 3=direita)
-	d := rand.IntN(4)
+	d := rng.IntN(4)
 	switch d {
 	case 0:
 		dy = -1 // Cima
@@ -55,136 +191,319 @@ func inimigoMover(jogo *Jogo, idx int) {
 		dx = 1 // Direita
 	}
 
-	// Calcula nova posição e move se válida
-	nx, ny := jogo.Entidades[idx].X+dx, jogo.Entidades[idx].Y+dy
-	if jogoPodeMoverParaInimigo(jogo, nx, ny, idx) {
-		jogoMoverElemento(jogo, jogo.Entidades[idx].X, jogo.Entidades[idx].Y, dx, dy, &jogo.Entidades[idx])
+	// Calcula nova posição e verifica se é válida
+	ent := jogo.Entidades[idx]
+	if !jogoPodeMoverParaInimigo(jogo, ent.X+dx, ent.Y+dy, ent.ID) {
+		return 0, 0
 	}
+	return dx, dy
 }
 
-// Move inimigo em direção ao personagem (perseguição)
-func inimigoPerseguir(jogo *Jogo, idx, px, py int) {
-	// Verifica se o índice ainda é válido
-	if idx >= len(jogo.Entidades) {
-		return
+// Calcula o passo que mais afasta o inimigo da célula informada (fuga)
+// Retorna o deslocamento (0, 0) se nenhum passo aumentar a distância
+func inimigoFugir(jogo *Jogo, idx int, de Ponto) (int, int) {
+	ent := jogo.Entidades[idx]
+	melhor, dist := Ponto{}, inimigoDetectaPersonagem(ent.X, ent.Y, de.X, de.Y)
+	for _, d := range direcoesCaminho {
+		nx, ny := ent.X+d.X, ent.Y+d.Y
+		if !jogoPodeMoverParaInimigo(jogo, nx, ny, ent.ID) {
+			continue
+		}
+		if nova := inimigoDetectaPersonagem(nx, ny, de.X, de.Y); nova > dist {
+			melhor, dist = d, nova
+		}
 	}
+	return melhor.X, melhor.Y
+}
 
-	dx, dy := 0, 0
+// Calcula o passo do inimigo até o próximo ponto da sua rota
+// Ao chegar a um ponto, passa para o seguinte conforme o modo da rota. Os
+// pontos não precisam ser vizinhos: o inimigo vai de um a outro pelo menor
+// caminho
+func inimigoSeguirRota(jogo *Jogo, idx int, mente *MenteInimigo) (int, int) {
+	ent := jogo.Entidades[idx]
+	rota := ent.Rota
+	if mente.Etapa >= len(rota.Pontos) {
+		mente.Etapa = 0
+	}
+	if rota.Pontos[mente.Etapa] == (Ponto{ent.X, ent.Y}) {
+		inimigoAvancarEtapa(mente, rota)
+	}
 
-	// Calcula direção horizontal
-	if px > jogo.Entidades[idx].X {
-		dx = 1
-	} else if px < jogo.Entidades[idx].X {
-		dx = -1
+	alvo := rota.Pontos[mente.Etapa]
+	return inimigoPerseguir(jogo, idx, alvo.X, alvo.Y, &mente.Caminho)
+}
+
+// Escolhe o próximo ponto da rota
+// No modo ciclo o último ponto leva de volta ao primeiro; no modo vaivem a
+// rota é percorrida de trás para frente ao chegar em uma das pontas
+func inimigoAvancarEtapa(mente *MenteInimigo, rota *Rota) {
+	if rota.Modo != RotaVaiVem {
+		mente.Etapa = (mente.Etapa + 1) % len(rota.Pontos)
+		return
 	}
 
-	// Calcula direção vertical
-	if py > jogo.Entidades[idx].Y {
-		dy = 1
-	} else if py < jogo.Entidades[idx].Y {
-		dy = -1
+	if mente.Etapa == len(rota.Pontos)-1 {
+		mente.Volta = true
+	} else if mente.Etapa == 0 {
+		mente.Volta = false
 	}
+	if mente.Volta {
+		mente.Etapa--
+	} else {
+		mente.Etapa++
+	}
+}
 
-	// Move em direção ao personagem se possível
-	nx, ny := jogo.Entidades[idx].X+dx, jogo.Entidades[idx].Y+dy
-	if jogoPodeMoverParaInimigo(jogo, nx, ny, idx) {
-		jogoMoverElemento(jogo, jogo.Entidades[idx].X, jogo.Entidades[idx].Y, dx, dy, &jogo.Entidades[idx])
+// Calcula o passo do inimigo em direção a uma célula (perseguição)
+// Segue o menor caminho em volta das paredes (veja caminhoProximoPasso),
+// sempre em uma das quatro direções. Retorna o deslocamento (0, 0) se não
+// houver caminho até a célula
+func inimigoPerseguir(jogo *Jogo, idx, px, py int, caminho *CaminhoInimigo) (int, int) {
+	ent := jogo.Entidades[idx]
+	alvo := inimigoAlvoOcupavel(jogo, ent, Ponto{px, py})
+	passo, ok := caminhoProximoPasso(jogo, ent.ID, caminho, Ponto{ent.X, ent.Y}, alvo)
+	if !ok {
+		return 0, 0
 	}
+	return passo.X - ent.X, passo.Y - ent.Y
+}
+
+// Escolhe a posição em que o inimigo cobre a célula alvo
+// Para inimigos de uma célula é o próprio alvo. Inimigos grandes preferem
+// ter o alvo no canto superior esquerdo e, se não couberem ali, testam as
+// demais posições do quadrado que ainda cobrem o alvo
+func inimigoAlvoOcupavel(jogo *Jogo, ent Entidade, alvo Ponto) Ponto {
+	t := arquetipoTamanho(ent)
+	for dy := 0; dy < t; dy++ {
+		for dx := 0; dx < t; dx++ {
+			p := Ponto{alvo.X - dx, alvo.Y - dy}
+			if t == 1 || jogoPodeMoverParaInimigo(jogo, p.X, p.Y, ent.ID) {
+				return p
+			}
+		}
+	}
+	return alvo
 }
 
 // ============================================================================
-// MÓDULO DE SISTEMA DE DANO
+// MÓDULO DE MÁQUINA DE ESTADOS
 // ============================================================================
 
-// Aplica dano ao jogador quando inimigo toca nele
-func inimigoAplicarDano(jogo *Jogo, inimigoIdx int, chanVida chan int) {
-	// Verifica se o índice ainda é válido
-	if inimigoIdx >= len(jogo.Entidades) {
-		return
+// Lê, sem bloquear, os estímulos que a simulação enviou desde a última vez
+// A simulação envia os estímulos antes de dar a vez ao inimigo, então todos
+// já estão no canal quando o quadro chega: os ruídos ouvidos desde a última
+// vez e, por último, o que o inimigo percebe no quadro
+func inimigoReceberEstimulos(estimulos <-chan EstimuloInimigo) []EstimuloInimigo {
+	var recebidos []EstimuloInimigo
+	for {
+		select {
+		case e := <-estimulos:
+			recebidos = append(recebidos, e)
+		default:
+			return recebidos
+		}
+	}
+}
+
+// Gera os estímulos do que o inimigo percebe no quadro atual
+// Usada pela simulação, que os envia ao inimigo junto com o quadro
+func inimigoPerceber(jogo *Jogo, mente *MenteInimigo, pos Ponto, visto bool) []EstimuloInimigo {
+	p := jogo.Entidades[0]
+	switch {
+	case visto:
+		return []EstimuloInimigo{{Tipo: EstimuloAvistou, Ponto: Ponto{p.X, p.Y}}}
+	case mente.Estado == EstadoPerseguindo || mente.Estado == EstadoAlerta:
+		return []EstimuloInimigo{{Tipo: EstimuloPerdeu}}
+	case mente.Estado == EstadoProcurando && !jogo.Tempo.Before(mente.Prazo):
+		return []EstimuloInimigo{{Tipo: EstimuloExpirou}}
+	case mente.Estado == EstadoRetornando && pos == mente.Posto:
+		return []EstimuloInimigo{{Tipo: EstimuloChegou}}
 	}
+	return nil
+}
 
-	// Verifica se inimigo está na mesma posição do personagem
-	if jogo.Entidades[inimigoIdx].X == jogo.Entidades[0].X &&
-		jogo.Entidades[inimigoIdx].Y == jogo.Entidades[0].Y {
+// Aplica a transição provocada por um estímulo
+// pos é a posição atual do inimigo e agora o instante do quadro
+//
+//	patrulha    --avistou/ruido--> alerta
+//	alerta      --avistou--------> perseguindo
+//	alerta      --perdeu---------> procurando
+//	perseguindo --perdeu---------> procurando
+//	procurando  --avistou--------> perseguindo
+//	procurando  --expirou--------> retornando
+//	retornando  --avistou/ruido--> alerta
+//	retornando  --chegou---------> patrulha
+func inimigoTransicao(mente *MenteInimigo, e EstimuloInimigo, pos Ponto, agora time.Time) {
+	switch e.Tipo {
+	case EstimuloAvistou:
+		mente.Alvo = e.Ponto
+		switch mente.Estado {
+		case EstadoPatrulha, EstadoRetornando:
+			inimigoDeixarPatrulha(mente, pos)
+			mente.Estado = EstadoAlerta
+		case EstadoAlerta, EstadoProcurando:
+			mente.Estado = EstadoPerseguindo
+		}
 
-		// Protege modificação da vida
-		mutexChan <- struct{}{}
+	case EstimuloRuido:
+		switch mente.Estado {
+		case EstadoPatrulha, EstadoRetornando:
+			inimigoDeixarPatrulha(mente, pos)
+			mente.Alvo = e.Ponto
+			mente.Estado = EstadoAlerta
+		case EstadoProcurando:
+			// Vai procurar onde ouviu a explosão
+			mente.Alvo = e.Ponto
+			mente.Prazo = agora.Add(TempoProcura)
+		}
 
-		// Cooldown de 2 segundos entre danos
-		agora := time.Now()
-		tempoDecorrido := agora.Sub(jogo.UltimoDano)
+	case EstimuloPerdeu:
+		if mente.Estado == EstadoPerseguindo || mente.Estado == EstadoAlerta {
+			mente.Estado = EstadoProcurando
+			mente.Prazo = agora.Add(TempoProcura)
+		}
 
-		// Aplica dano se cooldown passou e jogador tem vida
-		if tempoDecorrido >= 2*time.Second && jogo.Vida > 0 {
-			chanVida <- -1 // Envia sinal para diminuir vida
-			jogo.StatusMsg = "Dano recebido!"
-			// Atualiza tempo do último dano
-			jogo.UltimoDano = agora
+	case EstimuloExpirou:
+		// Um ruído recebido antes pode ter renovado o prazo da procura
+		if mente.Estado == EstadoProcurando && !agora.Before(mente.Prazo) {
+			mente.Estado = EstadoRetornando
 		}
 
-		<-mutexChan
+	case EstimuloChegou:
+		if mente.Estado == EstadoRetornando {
+			mente.Estado = EstadoPatrulha
+		}
 	}
 }
 
-// ============================================================================
-// MÓDULO DE PROCESSAMENTO DE AÇÕES
-// ============================================================================
-
-// Processa comportamento do inimigo baseado na posição do personagem
-func inimigoExecutarAcao(jogo *Jogo, idx int, posPersonagem <-chan [2]int, chanVida chan int) bool {
-	// Verifica se o índice ainda é válido (inimigo pode ter sido removido por explosão)
-	if idx >= len(jogo.Entidades) {
-		return false // Inimigo foi removido, encerra a goroutine
+// Guarda o ponto de patrulha ao sair dela
+// Um inimigo que ainda está voltando mantém o ponto antigo
+func inimigoDeixarPatrulha(mente *MenteInimigo, pos Ponto) {
+	if mente.Estado == EstadoPatrulha {
+		mente.Posto = pos
 	}
+}
 
-	logIdx := idx - 1 // Ajusta índice para o array de logs (inimigos começam no índice 1)
-
-	select {
-	case pos := <-posPersonagem:
-		// Verifica novamente antes de acessar (pode ter mudado durante o select)
-		if idx >= len(jogo.Entidades) {
-			return false
+// Calcula o passo do inimigo no estado atual
+// Retorna o deslocamento e a descrição do comportamento para os logs
+func inimigoAgir(jogo *Jogo, idx int, rng *rand.Rand, mente *MenteInimigo, dist int) (int, int, string) {
+	ent := jogo.Entidades[idx]
+	pos := Ponto{ent.X, ent.Y}
+	foge := arquetipoDe(ent).Foge
+
+	switch mente.Estado {
+	case EstadoAlerta:
+		return 0, 0, mente.Estado.String() // Para por uma vez ao notar algo
+
+	case EstadoPerseguindo:
+		if foge {
+			dx, dy := inimigoFugir(jogo, idx, mente.Alvo)
+			return dx, dy, fmt.Sprintf("Fugindo (dist: %d)", dist)
+		}
+		dx, dy := inimigoPerseguir(jogo, idx, mente.Alvo.X, mente.Alvo.Y, &mente.Caminho)
+		return dx, dy, fmt.Sprintf("%v (dist: %d)", mente.Estado, dist)
+
+	case EstadoProcurando:
+		restante := (mente.Prazo.Sub(jogo.Tempo) + time.Second - 1) / time.Second
+		log := fmt.Sprintf("%v (%ds)", mente.Estado, restante)
+		if pos != mente.Alvo && !foge {
+			if dx, dy := inimigoPerseguir(jogo, idx, mente.Alvo.X, mente.Alvo.Y, &mente.Caminho); dx != 0 || dy != 0 {
+				return dx, dy, log
+			}
 		}
+		// Chegou à última posição conhecida (ou não há caminho): vasculha em volta
+		dx, dy := inimigoMoverAleatorio(jogo, idx, rng)
+		return dx, dy, log
 
-		// Calcula distância até o personagem
-		dist := inimigoDetectaPersonagem(jogo.Entidades[idx].X, jogo.Entidades[idx].Y, pos[0], pos[1])
+	case EstadoRetornando:
+		dx, dy := inimigoPerseguir(jogo, idx, mente.Posto.X, mente.Posto.Y, &mente.Caminho)
+		return dx, dy, mente.Estado.String()
+	}
 
-		if dist <= 10 {
-			// Persegue se personagem estiver próximo
-			inimigoPerseguir(jogo, idx, pos[0], pos[1])
-			inimigoAplicarDano(jogo, idx, chanVida)
+	dx, dy := inimigoMover(jogo, idx, rng, mente)
+	return dx, dy, mente.Estado.String()
+}
 
-			// Atualiza log de comportamento
-			if logIdx >= 0 && logIdx < len(jogo.LogsInimigos) {
-				jogo.LogsInimigos[logIdx] = fmt.Sprintf("Perseguindo (dist: %d)", dist)
-			}
-			return true
-		} else {
-			// Verifica se ainda é válido antes de mover
-			if idx >= len(jogo.Entidades) {
-				return false
-			}
+// ============================================================================
+// MÓDULO DE SISTEMA DE DANO
+// ============================================================================
 
-			// Movimento aleatório se personagem estiver longe
-			inimigoMover(jogo, idx)
-			inimigoAplicarDano(jogo, idx, chanVida)
+// Calcula o dano que o inimigo causa com o passo (dx, dy)
+// Retorna o dano do seu tipo se ele tocar no personagem, ou zero. O
+// intervalo entre danos é controlado pela simulação ao receber o sinal
+func inimigoAplicarDano(jogo *Jogo, idx, dx, dy int) int {
+	ent := jogo.Entidades[idx]
+	ent.X, ent.Y = ent.X+dx, ent.Y+dy
+	if !jogoEntidadeOcupa(ent, jogo.Entidades[0].X, jogo.Entidades[0].Y) || jogo.Vida <= 0 {
+		return 0
+	}
+	return arquetipoDe(ent).Dano
+}
 
-			// Atualiza log de comportamento
-			if logIdx >= 0 && logIdx < len(jogo.LogsInimigos) {
-				jogo.LogsInimigos[logIdx] = "Random"
-			}
+// ============================================================================
+// MÓDULO DE PROCESSAMENTO DE AÇÕES
+// ============================================================================
+
+// Goroutine de um inimigo: a cada vez, lê sua memória do quadro e os
+// estímulos do seu canal, decide o movimento com seu próprio gerador
+// aleatório (derivado da semente e do ID) e devolve a memória no comando. Envia dano pelo canal chanVida e o alarme
+// aos ninhos pelo canal alarmes ao entrar em alerta
+func inimigoExecutar(id int, semente uint64, vez <-chan Jogo, estimulos <-chan EstimuloInimigo, sair <-chan struct{}, acoes chan<- Comando, chanVida chan<- int, alarmes chan<- struct{}) {
+	rng := rand.New(rand.NewPCG(semente, uint64(id)))
+
+	simulacaoAgirNaVez(vez, sair, acoes, func(quadro *Jogo) Comando {
+		recebidos := inimigoReceberEstimulos(estimulos)
+		var mente MenteInimigo
+		if idx := jogoBuscarEntidade(quadro, id); idx > 0 {
+			mente = quadro.Entidades[idx].Mente
 		}
-	default:
-		// Verifica se ainda é válido antes de mover
-		if idx >= len(jogo.Entidades) {
-			return false
+		antes := mente.Estado
+		cmd, dano := inimigoExecutarAcao(quadro, id, rng, &mente, recebidos)
+		cmd.Mente = mente
+		if dano > 0 {
+			chanVida <- -dano // Envia sinal para diminuir vida
 		}
+		if mente.Estado == EstadoAlerta && antes != EstadoAlerta {
+			alarmes <- struct{}{} // Chama reforços dos ninhos
+		}
+		return cmd
+	})
+}
 
-		// Movimento aleatório quando não há informação do personagem
-		inimigoMover(jogo, idx)
-		inimigoAplicarDano(jogo, idx, chanVida)
+// Decide o comportamento do inimigo baseado no seu estado e nos estímulos
+// recebidos da simulação, aplicados na ordem em que chegaram. Retorna o
+// comando de movimento e o dano que o passo causa ao personagem
+func inimigoExecutarAcao(jogo *Jogo, id int, rng *rand.Rand, mente *MenteInimigo, recebidos []EstimuloInimigo) (ComandoMoverInimigo, int) {
+	idx := jogoBuscarEntidade(jogo, id)
+	if idx <= 0 {
+		return ComandoMoverInimigo{ID: id}, 0 // Inimigo não está no quadro
+	}
 
+	// Atualiza o estado com os estímulos recebidos
+	ent, p := jogo.Entidades[idx], jogo.Entidades[0]
+	pos := Ponto{ent.X, ent.Y}
+	visto, dist := false, inimigoDetectaPersonagem(ent.X, ent.Y, p.X, p.Y)
+	for _, e := range recebidos {
+		visto = visto || e.Tipo == EstimuloAvistou
+		inimigoTransicao(mente, e, pos, jogo.Tempo)
+	}
+
+	// O chefe usa o ataque da sua fase em vez de andar, se puder
+	if cmd, ok := chefeAtacar(jogo, idx, mente, visto); ok {
+		return cmd, 0
+	}
+
+	// Atiradores disparam em vez de andar quando o personagem está na mira
+	if mente.Estado == EstadoPerseguindo {
+		if direcao, ok := projetilMirar(jogo, idx, visto, mente); ok {
+			mente.ProximoDisparo = jogo.Tempo.Add(RecargaDisparo)
+			log := fmt.Sprintf("Atirando (dist: %d)", dist)
+			return ComandoMoverInimigo{ID: id, Log: log, Disparo: direcao}, 0
+		}
 	}
 
-	return true
+	dx, dy, log := inimigoAgir(jogo, idx, rng, mente, dist)
+	dano := inimigoAplicarDano(jogo, idx, dx, dy)
+	return ComandoMoverInimigo{ID: id, DX: dx, DY: dy, Log: log}, dano
 }
@@ -0,0 +1,143 @@
+// inimigo_test.go - Testes da máquina de estados dos inimigos
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// Cada estado reage a cada estímulo como no diagrama de inimigoTransicao:
+// os estímulos que não levam a outro estado mantêm a memória como estava
+func TestInimigoTransicao(t *testing.T) {
+	const (
+		A = EstimuloAvistou
+		R = EstimuloRuido
+		P = EstimuloPerdeu
+		E = EstimuloExpirou
+		C = EstimuloChegou
+	)
+	agora := time.Unix(100, 0).UTC()
+	pos, postoAntigo := Ponto{2, 2}, Ponto{9, 9}
+	alvoAntigo, ponto := Ponto{1, 1}, Ponto{5, 5}
+
+	casos := []struct {
+		estado   EstadoInimigo
+		estimulo string
+		esperado EstadoInimigo
+		alvo     Ponto
+		posto    Ponto
+	}{
+		{EstadoPatrulha, A, EstadoAlerta, ponto, pos},
+		{EstadoPatrulha, R, EstadoAlerta, ponto, pos},
+		{EstadoPatrulha, P, EstadoPatrulha, alvoAntigo, postoAntigo},
+		{EstadoPatrulha, E, EstadoPatrulha, alvoAntigo, postoAntigo},
+		{EstadoPatrulha, C, EstadoPatrulha, alvoAntigo, postoAntigo},
+
+		{EstadoAlerta, A, EstadoPerseguindo, ponto, postoAntigo},
+		{EstadoAlerta, R, EstadoAlerta, alvoAntigo, postoAntigo},
+		{EstadoAlerta, P, EstadoProcurando, alvoAntigo, postoAntigo},
+		{EstadoAlerta, E, EstadoAlerta, alvoAntigo, postoAntigo},
+		{EstadoAlerta, C, EstadoAlerta, alvoAntigo, postoAntigo},
+
+		{EstadoPerseguindo, A, EstadoPerseguindo, ponto, postoAntigo},
+		{EstadoPerseguindo, R, EstadoPerseguindo, alvoAntigo, postoAntigo},
+		{EstadoPerseguindo, P, EstadoProcurando, alvoAntigo, postoAntigo},
+		{EstadoPerseguindo, E, EstadoPerseguindo, alvoAntigo, postoAntigo},
+		{EstadoPerseguindo, C, EstadoPerseguindo, alvoAntigo, postoAntigo},
+
+		{EstadoProcurando, A, EstadoPerseguindo, ponto, postoAntigo},
+		{EstadoProcurando, R, EstadoProcurando, ponto, postoAntigo},
+		{EstadoProcurando, P, EstadoProcurando, alvoAntigo, postoAntigo},
+		{EstadoProcurando, E, EstadoRetornando, alvoAntigo, postoAntigo},
+		{EstadoProcurando, C, EstadoProcurando, alvoAntigo, postoAntigo},
+
+		// Quem ainda volta ao posto mantém o ponto antigo ao deixá-lo de novo
+		{EstadoRetornando, A, EstadoAlerta, ponto, postoAntigo},
+		{EstadoRetornando, R, EstadoAlerta, ponto, postoAntigo},
+		{EstadoRetornando, P, EstadoRetornando, alvoAntigo, postoAntigo},
+		{EstadoRetornando, E, EstadoRetornando, alvoAntigo, postoAntigo},
+		{EstadoRetornando, C, EstadoPatrulha, alvoAntigo, postoAntigo},
+	}
+
+	for _, caso := range casos {
+		// O prazo da procura já acabou, para que "expirou" valha
+		mente := MenteInimigo{Estado: caso.estado, Alvo: alvoAntigo, Posto: postoAntigo, Prazo: agora}
+		inimigoTransicao(&mente, EstimuloInimigo{Tipo: caso.estimulo, Ponto: ponto}, pos, agora)
+
+		if mente.Estado != caso.esperado || mente.Alvo != caso.alvo || mente.Posto != caso.posto {
+			t.Errorf("%v --%s--> %v (alvo %v, posto %v), esperado %v (alvo %v, posto %v)",
+				caso.estado, caso.estimulo, mente.Estado, mente.Alvo, mente.Posto,
+				caso.esperado, caso.alvo, caso.posto)
+		}
+		// Quem passa a procurar tem o prazo inteiro pela frente
+		if caso.esperado == EstadoProcurando && caso.estado != EstadoProcurando && !mente.Prazo.Equal(agora.Add(TempoProcura)) {
+			t.Errorf("%v --%s--> procura até %v, esperado %v", caso.estado, caso.estimulo, mente.Prazo, agora.Add(TempoProcura))
+		}
+	}
+}
+
+// Um ruído recebido na mesma vez renova o prazo da procura, e o fim do prazo
+// percebido no quadro deixa de valer
+func TestInimigoRuidoRenovaProcura(t *testing.T) {
+	agora := time.Unix(100, 0).UTC()
+	mente := MenteInimigo{Estado: EstadoProcurando, Prazo: agora}
+	for _, e := range []EstimuloInimigo{{Tipo: EstimuloRuido, Ponto: Ponto{5, 5}}, {Tipo: EstimuloExpirou}} {
+		inimigoTransicao(&mente, e, Ponto{2, 2}, agora)
+	}
+	if mente.Estado != EstadoProcurando || !mente.Prazo.Equal(agora.Add(TempoProcura)) {
+		t.Errorf("estado %v, procura até %v; esperado %v até %v", mente.Estado, mente.Prazo, EstadoProcurando, agora.Add(TempoProcura))
+	}
+}
+
+// A simulação envia pelo canal do inimigo o que ele percebe no quadro
+func TestInimigoRecebePercepcoes(t *testing.T) {
+	jogo := testeCarregarMapa(t, "testdata/fantasma.txt")
+	id := testeBuscarTipo(t, &jogo, TipoInimigo)
+	canais := &canaisInimigo{estimulos: make(chan EstimuloInimigo, CapacidadeEstimulos+1)}
+
+	jogo.Entidades[0].X, jogo.Entidades[0].Y = 5, 2
+	simulacaoEnviarPercepcoes(canais, &jogo, id)
+	recebidos := inimigoReceberEstimulos(canais.estimulos)
+	if len(recebidos) != 1 || recebidos[0] != (EstimuloInimigo{Tipo: EstimuloAvistou, Ponto: Ponto{5, 2}}) {
+		t.Errorf("personagem ao lado: estímulos %v, esperado avistou em (5, 2)", recebidos)
+	}
+
+	jogo.Entidades[jogoBuscarEntidade(&jogo, id)].Mente.Estado = EstadoPerseguindo
+	jogo.Entidades[0].X, jogo.Entidades[0].Y = 1, 1
+	simulacaoEnviarPercepcoes(canais, &jogo, id)
+	if recebidos := inimigoReceberEstimulos(canais.estimulos); len(recebidos) != 1 || recebidos[0].Tipo != EstimuloPerdeu {
+		t.Errorf("personagem atrás da parede: estímulos %v, esperado perdeu", recebidos)
+	}
+}
+
+// A rota em ciclo volta ao primeiro ponto depois do último; a vaivem inverte
+// o sentido em cada ponta, e a memória guarda o sentido para continuar dele
+func TestInimigoAvancarEtapa(t *testing.T) {
+	pontos := []Ponto{{1, 1}, {3, 1}, {3, 3}, {1, 3}}
+	casos := []struct {
+		modo   string
+		inicio MenteInimigo
+		etapas []int
+		volta  bool
+	}{
+		{RotaCiclo, MenteInimigo{}, []int{1, 2, 3, 0, 1}, false},
+		{RotaVaiVem, MenteInimigo{}, []int{1, 2, 3, 2, 1, 0, 1}, false},
+		{RotaVaiVem, MenteInimigo{Etapa: 2, Volta: true}, []int{1, 0, 1}, false},
+		{RotaVaiVem, MenteInimigo{Etapa: 1, Volta: true}, []int{0, 1, 2, 3, 2}, true},
+	}
+
+	for _, caso := range casos {
+		rota := &Rota{Modo: caso.modo, Pontos: pontos}
+		mente := caso.inicio
+		var etapas []int
+		for range caso.etapas {
+			inimigoAvancarEtapa(&mente, rota)
+			etapas = append(etapas, mente.Etapa)
+		}
+		if !slices.Equal(etapas, caso.etapas) || mente.Volta != caso.volta {
+			t.Errorf("%s a partir de %+v: etapas %v (volta %v), esperado %v (volta %v)",
+				caso.modo, caso.inicio, etapas, mente.Volta, caso.etapas, caso.volta)
+		}
+	}
+}
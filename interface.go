@@ -5,6 +5,9 @@ package main
 
 import (
 	"fmt"
+	"time"
+	"unicode/utf8"
+
 	"github.com/nsf/termbox-go"
 )
 
@@ -13,8 +16,9 @@ type Cor = termbox.Attribute
 
 // Representa uma ação detectada do teclado
 type EventoTeclado struct {
-	Tipo  string // Tipos: "sair", "mover", "bomba"
-	Tecla rune   // Tecla pressionada (usado para movimento)
+	Tipo  string        // Tipos: "sair", "mover", "bomba", "forma", "salvar", "novo"
+	Tecla rune          // Tecla pressionada (usado para movimento)
+	Tempo time.Duration // Momento do evento desde o início do jogo (usado por roteiros)
 }
 
 // ==============================================================================
@@ -30,6 +34,10 @@ const (
 	CorFundoParede     = termbox.ColorDarkGray
 	CorTexto           = termbox.ColorDarkGray
 	CorBranco          = termbox.ColorWhite
+	CorAmarelo         = termbox.ColorYellow
+	CorMagenta         = termbox.ColorMagenta
+	CorCiano           = termbox.ColorCyan
+	CorAzul            = termbox.ColorBlue
 )
 
 // =============================================================================
@@ -67,14 +75,26 @@ func interfaceLerEventoTeclado() EventoTeclado {
 	if ev.Key == termbox.KeyEsc {
 		return EventoTeclado{Tipo: "sair"}
 	}
-	
-	// Detecta tecla E para colocar bomba
-	if ev.Ch == 'e' || ev.Ch == 'E' {
-		return EventoTeclado{Tipo: "bomba", Tecla: ev.Ch}
+
+	// Detecta tecla F5 para salvar o jogo
+	if ev.Key == termbox.KeyF5 {
+		return EventoTeclado{Tipo: "salvar"}
 	}
 	
-	// Para outras teclas, retorna como evento de movimento
-	return EventoTeclado{Tipo: "mover", Tecla: ev.Ch}
+	// Demais teclas colocam bomba (E) ou movem o personagem
+	return entradaEventoDeTecla(ev.Ch)
+}
+
+// Lê o teclado continuamente e envia cada evento pelo canal
+// Executada em uma goroutine própria para que a simulação não fique bloqueada
+func interfaceLerEventos(eventos chan<- EventoTeclado) {
+	for {
+		evento := interfaceLerEventoTeclado()
+		if evento.Tipo == "" {
+			continue // Ignora eventos que não são de teclado
+		}
+		eventos <- evento
+	}
 }
 
 // ==============================================================================
@@ -82,6 +102,14 @@ func interfaceLerEventoTeclado() EventoTeclado {
 // =============================================================================
 
 
+// Desenha cada quadro recebido da simulação
+// Retorna quando o canal de quadros é fechado (fim da simulação)
+func interfaceExecutarRenderizador(quadros <-chan Jogo) {
+	for quadro := range quadros {
+		interfaceDesenharJogo(&quadro)
+	}
+}
+
 // Função principal de renderização que coordena todos os elementos visuais
 func interfaceDesenharJogo(jogo *Jogo) {
 	// Limpa a tela antes de desenhar
@@ -90,6 +118,9 @@ func interfaceDesenharJogo(jogo *Jogo) {
 	// Se o jogo terminou, mostra apenas a tela de fim de jogo
 	if jogo.JogoTerminado {
 		interfaceDesenharTelaFimJogo(jogo)
+	} else if jogoEmIntroducao(jogo) {
+		// Apresentação do nível da campanha antes de o jogo começar
+		interfaceDesenharTelaIntroducao(jogo)
 	} else {
 		// Renderiza o mapa base
 		interfaceRenderizarMapa(jogo)
@@ -97,8 +128,9 @@ func interfaceDesenharJogo(jogo *Jogo) {
 		// Renderiza bombas (antes das entidades para que fiquem atrás)
 		interfaceRenderizarBombas(jogo)
 		
-		// Renderiza as entidades (jogador e inimigos)
+		// Renderiza as entidades (jogador e inimigos) e os projéteis
 		interfaceRenderizarEntidades(jogo)
+		interfaceRenderizarProjeteis(jogo)
 		
 		// Renderiza explosões (por cima de tudo)
 		interfaceRenderizarExplosoes(jogo)
@@ -122,10 +154,23 @@ func interfaceRenderizarMapa(jogo *Jogo) {
 }
 
 // Desenha todas as entidades do jogo
+// Entidades grandes (como o chefe) são desenhadas em todas as suas células
 func interfaceRenderizarEntidades(jogo *Jogo) {
 	for i := 0; i < len(jogo.Entidades); i++ {
 		entidade := &jogo.Entidades[i]
-		interfaceDesenharElemento(entidade.X, entidade.Y, entidade.Sprite)
+		tamanho := arquetipoTamanho(*entidade)
+		for y := entidade.Y; y < entidade.Y+tamanho; y++ {
+			for x := entidade.X; x < entidade.X+tamanho; x++ {
+				interfaceDesenharElemento(x, y, entidade.Sprite)
+			}
+		}
+	}
+}
+
+// Desenha os projéteis em voo
+func interfaceRenderizarProjeteis(jogo *Jogo) {
+	for _, p := range jogo.Projeteis {
+		interfaceDesenharElemento(p.X, p.Y, projetilSprite(p))
 	}
 }
 
@@ -153,17 +198,17 @@ func interfaceRenderizarExplosoes(jogo *Jogo) {
 
 // Limpa completamente a tela do terminal -> não sei como
 func interfaceLimparTela() {
-	termbox.Clear(CorPadrao, CorPadrao)
+	tela.Limpar()
 }
 
 // Força a atualização visual da tela
 func interfaceAtualizarTela() {
-	termbox.Flush()
+	tela.Atualizar()
 }
 
 // Desenha um elemento específico na posição (x, y)
 func interfaceDesenharElemento(x, y int, elem Elemento) {
-	termbox.SetCell(x, y, elem.simbolo, elem.cor, elem.corFundo)
+	tela.DesenharCelula(x, y, elem.simbolo, elem.cor, elem.corFundo)
 }
 
 // Mostra a direção atual do personagem
@@ -184,7 +229,7 @@ func interfaceDesenharIndicadorDirecao(jogo *Jogo) {
 	// Verifica se há um inimigo na posição do indicador
 	temInimigo := false
 	for i := 1; i < len(jogo.Entidades); i++ {
-		if jogo.Entidades[i].X == indicadorX && jogo.Entidades[i].Y == indicadorY {
+		if jogoEntidadeOcupa(jogo.Entidades[i], indicadorX, indicadorY) {
 			temInimigo = true
 			break
 		}
@@ -212,16 +257,31 @@ func interfacePosicaoValida(jogo *Jogo, x, y int) bool {
 func interfaceDesenharBarraDeStatus(jogo *Jogo) {
 	linhaBase := len(jogo.Mapa) + 2
 	
+	// Desenha o nome e o autor do mapa, se o cabeçalho informar
+	if jogo.Cabecalho.Nome != "" {
+		titulo := "Mapa: " + jogo.Cabecalho.Nome
+		if jogo.Cabecalho.Autor != "" {
+			titulo += " (por " + jogo.Cabecalho.Autor + ")"
+		}
+		interfaceDesenharTexto(0, len(jogo.Mapa)+1, titulo, CorTexto)
+	}
+	
 	// Desenha logs dos inimigos
 	interfaceDesenharLogsInimigos(jogo, linhaBase)
 	
 	// Desenha barra de vida
-	linhaVida := linhaBase + len(jogo.LogsInimigos) + 1
+	numInimigos := len(jogo.Entidades) - 1 // Uma linha de log por inimigo
+	linhaVida := linhaBase + numInimigos + 1
 	interfaceDesenharBarraVida(jogo, linhaVida)
+	interfaceDesenharBarraChefe(jogo, linhaVida+1)
 	
-	// Desenha instruções de controle
+	// Desenha instruções de controle (ou os controles da reprodução de replay)
 	linhaInstrucoes := linhaVida + 2
-	interfaceDesenharInstrucoes(linhaInstrucoes)
+	if jogo.InfoReplay != "" {
+		interfaceDesenharTexto(0, linhaInstrucoes, jogo.InfoReplay, CorBranco)
+	} else {
+		interfaceDesenharInstrucoes(linhaInstrucoes)
+	}
 	
 	// Desenha mensagem de fim de jogo se o jogo terminou
 	if jogo.JogoTerminado {
@@ -232,15 +292,20 @@ func interfaceDesenharBarraDeStatus(jogo *Jogo) {
 
 // Exibe os logs de atividade dos inimigos
 func interfaceDesenharLogsInimigos(jogo *Jogo, linhaInicial int) {
-	for idx, log := range jogo.LogsInimigos {
+	// Percorre os inimigos na ordem de jogo.Entidades (o personagem é o índice 0)
+	for idx, ent := range jogo.Entidades[1:] {
 		linha := linhaInicial + idx
 		
 		// Desenha rótulo do inimigo
-		rotulo := fmt.Sprintf("Inimigo %d: ", idx+1)
+		arquetipo := arquetipoDe(ent)
+		rotulo := fmt.Sprintf("%s %d: ", arquetipo.Rotulo, ent.ID)
+		if arquetipo.Vida > 1 {
+			rotulo = fmt.Sprintf("%s %d (vida %d): ", arquetipo.Rotulo, ent.ID, ent.Vida)
+		}
 		interfaceDesenharTexto(0, linha, rotulo, CorTexto)
 		
 		// Desenha log do inimigo
-		interfaceDesenharTexto(len(rotulo), linha, log, CorVerde)
+		interfaceDesenharTexto(len(rotulo), linha, jogo.LogsInimigos[ent.ID], CorVerde)
 	}
 }
 
@@ -252,13 +317,66 @@ func interfaceDesenharBarraVida(jogo *Jogo, linha int) {
 	
 	// Desenha corações representando a vida
 	for i := 0; i < jogo.Vida; i++ {
-		termbox.SetCell(len(vidaTexto)+i, linha, '♥', CorVermelho, CorPadrao)
+		tela.DesenharCelula(len(vidaTexto)+i, linha, '♥', CorVermelho, CorPadrao)
+	}
+
+	// Desenha a mochila de bombas ao lado dos corações: as bombas usadas
+	// ficam escuras até serem recarregadas
+	coluna := len(vidaTexto) + jogo.Cabecalho.VidaMaxima + 3
+	bombasTexto := "Bombas: "
+	interfaceDesenharTexto(coluna, linha, bombasTexto, CorTexto)
+	coluna += utf8.RuneCountInString(bombasTexto)
+	for i := 0; i < jogo.Cabecalho.CapacidadeBombas; i++ {
+		cor := BombaElem.cor
+		if i >= jogo.BombasMochila {
+			cor = CorCinzaEscuro
+		}
+		tela.DesenharCelula(coluna+i, linha, BombaElem.simbolo, cor, CorPadrao)
 	}
+	coluna += jogo.Cabecalho.CapacidadeBombas + 3
+
+	// Desenha pontuação e, em campanhas, o nível atual
+	info := fmt.Sprintf("Pontos: %d   Bomba: %s", jogo.Pontos, jogo.FormaBomba)
+	if jogo.Campanha.Total > 0 {
+		info += fmt.Sprintf("   Nível %d de %d", jogo.Campanha.Nivel, jogo.Campanha.Total)
+	}
+	if len(jogo.Ninhos) > 0 {
+		info += fmt.Sprintf("   Ninhos: %d", len(jogo.Ninhos))
+	}
+	if jogo.Cadeia > 1 && len(jogo.Explosoes) > 0 {
+		info += fmt.Sprintf("   Cadeia: %d bombas", jogo.Cadeia)
+	}
+	interfaceDesenharTexto(coluna, linha, info, CorTexto)
+}
+
+// Exibe a vida e a fase do chefe, se houver um no mapa
+// Cada bloco da barra é uma vida; os blocos escuros já foram perdidos
+func interfaceDesenharBarraChefe(jogo *Jogo, linha int) {
+	idx := jogoBuscarChefe(jogo)
+	if idx < 0 {
+		return
+	}
+
+	chefe := jogo.Entidades[idx]
+	texto := "Chefe: "
+	interfaceDesenharTexto(0, linha, texto, CorTexto)
+
+	maxima := arquetipoDe(chefe).Vida
+	for i := 0; i < maxima; i++ {
+		cor := CorVermelho
+		if i >= chefe.Vida {
+			cor = CorCinzaEscuro
+		}
+		tela.DesenharCelula(len(texto)+i, linha, '█', cor, CorPadrao)
+	}
+
+	info := fmt.Sprintf("%d/%d   Fase: %v", chefe.Vida, maxima, chefeFase(chefe))
+	interfaceDesenharTexto(len(texto)+maxima+1, linha, info, CorTexto)
 }
 
 // Exibe as instruções de controle do jogo
 func interfaceDesenharInstrucoes(linha int) {
-	instrucoes := "Use WASD para mover. E para bomba. ESC para sair."
+	instrucoes := "Use WASD para mover. E para bomba. Q troca a bomba. N para novo nível. F5 para salvar. ESC para sair."
 	interfaceDesenharTexto(0, linha, instrucoes, CorTexto)
 }
 
@@ -266,7 +384,7 @@ func interfaceDesenharInstrucoes(linha int) {
 func interfaceDesenharMensagemFimJogo(jogo *Jogo, linha int) {
 	// Determina a cor baseada no tipo de mensagem
 	var cor Cor
-	if jogo.StatusMsg == "VITORIA! Todos os inimigos foram eliminados!" {
+	if jogo.Venceu {
 		cor = CorVerde
 	} else {
 		cor = CorVermelho
@@ -280,31 +398,62 @@ func interfaceDesenharMensagemFimJogo(jogo *Jogo, linha int) {
 func interfaceDesenharTelaFimJogo(jogo *Jogo) {
 	// Determina a cor baseada no tipo de mensagem
 	var cor Cor
-	if jogo.StatusMsg == "VITORIA! Todos os inimigos foram eliminados!" {
+	if jogo.Venceu {
 		cor = CorVerde
 	} else {
 		cor = CorVermelho
 	}
 	
 	// Centraliza a mensagem na tela
-	largura, altura := termbox.Size()
+	largura, altura := tela.Tamanho()
 	linhaMsg := altura / 2
 	colunaMsg := (largura - len(jogo.StatusMsg)) / 2
 	
 	// Desenha a mensagem principal centralizada
 	interfaceDesenharTexto(colunaMsg, linhaMsg, jogo.StatusMsg, cor)
 	
+	// Desenha a pontuação final (e o progresso na campanha)
+	pontos := fmt.Sprintf("Pontuação final: %d", jogo.Pontos)
+	if jogo.Campanha.Total > 0 {
+		nivel := jogo.Campanha.Nivel
+		if !jogo.Campanha.Concluida {
+			nivel-- // O nível atual não foi vencido
+		}
+		pontos += fmt.Sprintf(" - %d de %d níveis concluídos", nivel, jogo.Campanha.Total)
+	}
+	interfaceDesenharTextoCentralizado(linhaMsg+1, pontos, CorTexto)
+	
 	// Desenha instruções para sair
-	instrucaoSair := "Pressione ESC para sair"
-	colunaSair := (largura - len(instrucaoSair)) / 2
-	interfaceDesenharTexto(colunaSair, linhaMsg+2, instrucaoSair, CorTexto)
+	instrucaoSair := "Pressione N para um novo nível ou ESC para sair"
+	if jogo.Campanha.Total > 0 {
+		instrucaoSair = "Pressione ESC para sair"
+	}
+	interfaceDesenharTextoCentralizado(linhaMsg+3, instrucaoSair, CorTexto)
+}
+
+// Desenha a apresentação do nível da campanha, que substitui o mapa
+func interfaceDesenharTelaIntroducao(jogo *Jogo) {
+	_, altura := tela.Tamanho()
+	linha := altura/2 - 2
+	
+	interfaceDesenharTextoCentralizado(linha, jogo.Campanha.Nome, CorTexto)
+	nivel := fmt.Sprintf("Nível %d de %d", jogo.Campanha.Nivel, jogo.Campanha.Total)
+	interfaceDesenharTextoCentralizado(linha+2, nivel, CorVerde)
+	interfaceDesenharTextoCentralizado(linha+3, jogo.Campanha.NomeNivel, CorBranco)
+	
+	info := fmt.Sprintf("Vida: %d   Pontos: %d", jogo.Vida, jogo.Pontos)
+	interfaceDesenharTextoCentralizado(linha+5, info, CorTexto)
+}
+
+// Desenha um texto centralizado horizontalmente na linha informada
+func interfaceDesenharTextoCentralizado(linha int, texto string, cor Cor) {
+	largura, _ := tela.Tamanho()
+	interfaceDesenharTexto((largura-utf8.RuneCountInString(texto))/2, linha, texto, cor)
 }
 
 // Função auxiliar para desenhar texto na tela
 func interfaceDesenharTexto(x, y int, texto string, cor Cor) {
-	for i, c := range texto {
-		termbox.SetCell(x+i, y, c, cor, CorPadrao)
-	}
+	tela.DesenharTexto(x, y, texto, cor, CorPadrao)
 }
 
 // ============================================================================
@@ -328,7 +477,7 @@ func interfaceMostrarFimDeJogo(vitoria bool) {
 	}
 	
 	// Calcula posição central da tela
-	largura, altura := termbox.Size()
+	largura, altura := tela.Tamanho()
 	x := (largura - len(mensagem)) / 2
 	y := altura / 2
 	
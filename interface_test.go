@@ -0,0 +1,124 @@
+// interface_test.go - Testes do desenho dos quadros contra arquivos de referência
+// Depois de mudar o desenho de propósito, regrave as referências com
+// "go test -run Interface -atualizar"
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+var atualizar = flag.Bool("atualizar", false, "regrava os arquivos de referência (testdata/*.golden)")
+
+// Monta um jogo fixo a partir do mapa de teste da interface
+// O mapa tem vegetação, cura, parede frágil, ninho, um inimigo e o chefe
+func testeJogoInterface(t *testing.T) Jogo {
+	t.Helper()
+	jogo := testeCarregarMapa(t, "testdata/interface.txt")
+	jogo.Tempo = relogioSimuladoNovo().Agora()
+	jogo.Direcao = 'd'
+	for _, ent := range jogo.Entidades[1:] {
+		jogo.LogsInimigos[ent.ID] = "Patrulhando"
+	}
+	return jogo
+}
+
+// Desenha o quadro em uma tela em memória e a retorna
+func testeDesenharMemoria(jogo Jogo) *RenderizadorMemoria {
+	anterior := tela
+	defer func() { tela = anterior }()
+
+	memoria := renderizadorMemoriaNovo(LarguraTelaMemoria, AlturaTelaMemoria)
+	tela = memoria
+	interfaceDesenharJogo(&jogo)
+	return memoria
+}
+
+// Desenha o quadro em uma tela em memória e retorna o texto exibido
+func testeDesenhar(jogo Jogo) string {
+	return testeDesenharMemoria(jogo).Texto()
+}
+
+// Procura o texto na tela e retorna a posição do caractere logo depois dele
+func testeLocalizar(t *testing.T, memoria *RenderizadorMemoria, texto string) (int, int) {
+	t.Helper()
+	for y, linha := range strings.Split(memoria.Texto(), "\n") {
+		if i := strings.Index(linha, texto); i >= 0 {
+			return utf8.RuneCountInString(linha[:i] + texto), y
+		}
+	}
+	t.Fatalf("texto %q não encontrado na tela", texto)
+	return 0, 0
+}
+
+// Compara o texto desenhado com o arquivo de referência em testdata
+func testeCompararReferencia(t *testing.T, nome, texto string) {
+	t.Helper()
+	arquivo := filepath.Join("testdata", nome+".golden")
+	if *atualizar {
+		if err := os.WriteFile(arquivo, []byte(texto), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	esperado, err := os.ReadFile(arquivo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if texto != string(esperado) {
+		t.Errorf("quadro diferente de %s:\n%s\n--- esperado ---\n%s", arquivo, texto, esperado)
+	}
+}
+
+// Quadro do início do jogo: mapa, entidades, indicador de direção e barra de status
+func TestInterfaceDesenharJogo(t *testing.T) {
+	jogo := testeJogoInterface(t)
+	testeCompararReferencia(t, "interface_inicio", testeDesenhar(jogo))
+}
+
+// Quadro no meio da partida: bomba e explosão no mapa e, na barra de status,
+// vida perdida, mochila com uma bomba usada, pontuação, nível da campanha,
+// ninhos restantes, reação em cadeia e a barra de vida do chefe já ferido
+func TestInterfaceDesenharHUD(t *testing.T) {
+	jogo := testeJogoInterface(t)
+	jogo.Vida = 2
+	jogo.Pontos = 1250
+	jogo.BombasMochila = 2
+	jogo.FormaBomba = FormaCruz
+	jogo.Campanha = InfoCampanha{Nome: "Teste", NomeNivel: "Teste da interface", Nivel: 2, Total: 3}
+	jogo.Bombas = []Bomba{{X: 2, Y: 4, TempoVida: jogo.Tempo, Ativa: true, Forma: FormaCruz}}
+	jogo.Explosoes = []Explosao{
+		{X: 6, Y: 4, TempoVida: jogo.Tempo.Add(-100 * time.Millisecond), Ativa: true},
+		{X: 7, Y: 4, TempoVida: jogo.Tempo.Add(-100 * time.Millisecond), Ativa: true},
+	}
+	jogo.Cadeia = 2
+
+	idx := jogoBuscarChefe(&jogo)
+	if idx < 0 {
+		t.Fatal("mapa de teste sem chefe")
+	}
+	jogo.Entidades[idx].Vida = 7 // Fase de invocação
+
+	memoria := testeDesenharMemoria(jogo)
+	testeCompararReferencia(t, "interface_hud", memoria.Texto())
+
+	// As bombas usadas e a vida perdida do chefe aparecem só na cor
+	x, y := testeLocalizar(t, memoria, "Bombas: ")
+	for i, cor := range []Cor{CorVermelho, CorVermelho, CorCinzaEscuro} {
+		if c := memoria.Celula(x+i, y); c.Cor != cor {
+			t.Errorf("bomba %d da mochila com cor %v, esperada %v", i+1, c.Cor, cor)
+		}
+	}
+	x, y = testeLocalizar(t, memoria, "Chefe: ")
+	if c := memoria.Celula(x+6, y); c.Cor != CorVermelho {
+		t.Errorf("sétima vida do chefe com cor %v, esperada %v", c.Cor, CorVermelho)
+	}
+	if c := memoria.Celula(x+7, y); c.Cor != CorCinzaEscuro {
+		t.Errorf("oitava vida do chefe com cor %v, esperada %v", c.Cor, CorCinzaEscuro)
+	}
+}
@@ -2,14 +2,11 @@
 package main
 
 import (
-	"bufio"
-	"os"
+	"fmt"
+	"strings"
 	"time"
 )
 
-// Canal para exclusão mútua - funciona como semáforo binário
-var mutexChan = make(chan struct{}, 1)
-
 // Elemento representa qualquer objeto do mapa (parede, personagem, vegetação, etc)
 type Elemento struct {
 	simbolo  rune
@@ -18,10 +15,17 @@ type Elemento struct {
 	tangivel bool // Indica se o elemento bloqueia passagem
 }
 
+// Entidade representa o personagem ou um inimigo no mapa
 type Entidade struct {
+	ID             int // identificador único e estável da entidade
 	Sprite         Elemento
 	X, Y           int
 	UltimoVisitado Elemento
+	Rota           *Rota        // rota de patrulha do inimigo (nil: anda ao acaso)
+	Tipo           string       // tipo do inimigo (veja arquetiposInimigo); vazio para o personagem
+	Vida           int          // explosões que o inimigo ainda aguenta
+	Ninho          int          // ID do ninho ou do chefe que gerou o inimigo (zero se veio do mapa)
+	Mente          MenteInimigo // memória do inimigo entre as vezes de agir (veja inimigoExecutar)
 }
 
 // Bomba representa uma bomba no jogo
@@ -29,6 +33,7 @@ type Bomba struct {
 	X, Y      int       // Posição da bomba
 	TempoVida time.Time // Quando a bomba foi colocada
 	Ativa     bool      // Se a bomba está ativa
+	Forma     string    // Forma da explosão (veja FormasBomba)
 }
 
 // Explosao representa uma explosão temporária
@@ -36,23 +41,62 @@ type Explosao struct {
 	X, Y      int       // Posição da explosão
 	TempoVida time.Time // Quando a explosão começou
 	Ativa     bool      // Se a explosão está ativa
+	Pisao     bool      // Explosão do pisão do chefe (fere mesmo sem fogo amigo)
 }
 
 // Jogo contém o estado atual do jogo
 type Jogo struct {
-	Mapa           [][]Elemento // grade 2D representando o mapa
-	Direcao        rune         // direção atual do personagem (w, a, s, d)
-	StatusMsg      string       // mensagem para a barra de status
-	Entidades      []Entidade   // posicoes dos inimigos e jogador ([0] é o jogador)
-	LogsInimigos   []string     // logs de comportamento dos inimigos (aleatório/perseguindo)
-	Vida           int          // vida atual do jogador (máximo 3 corações)
-	UltimoDano     time.Time    // timestamp do último dano recebido
-	CuraUsada      bool         // indica se a cura já foi utilizada (uso único)
-	Bombas         []Bomba      // bombas ativas no jogo
-	Explosoes      []Explosao   // explosões ativas no jogo
-	JogoTerminado  bool         // indica se o jogo terminou (vitória ou derrota)
+	Mapa          [][]Elemento   // grade 2D representando o mapa
+	VersaoMapa    int            // conta as células do mapa trocadas (invalida os caminhos guardados)
+	Direcao       rune           // direção atual do personagem (w, a, s, d)
+	StatusMsg     string         // mensagem para a barra de status
+	Entidades     []Entidade     // posicoes dos inimigos e jogador ([0] é o jogador)
+	ProximoID     int            // próximo identificador livre para novas entidades
+	LogsInimigos  map[int]string // logs de comportamento dos inimigos por ID (estado atual)
+	Vida          int            // vida atual do jogador (máximo 3 corações)
+	UltimoDano    time.Time      // timestamp do último dano recebido
+	CuraUsada     bool           // indica se a cura já foi utilizada (uso único)
+	Bombas        []Bomba        // bombas ativas no jogo
+	Explosoes     []Explosao     // explosões ativas no jogo
+	Projeteis     []Projetil     // projéteis em voo, disparados pelos inimigos
+	Ninhos        []Ninho        // ninhos que ainda não foram destruídos
+	Chamas        []Chama        // células de vegetação pegando fogo
+	Cadeia        int            // bombas da maior reação em cadeia do último passo com explosões
+	FormaBomba    string         // forma das próximas bombas do jogador (tecla Q)
+	BombasMochila int            // bombas que o jogador ainda pode colocar
+	RecargaDesde  time.Time      // início da recarga da próxima bomba (zero com a mochila cheia)
+	JogoTerminado bool           // indica se o jogo terminou (vitória ou derrota)
+	Venceu        bool           // indica se o jogo terminou com vitória
+	Pontos        int            // pontuação acumulada (mantida entre os níveis de uma campanha)
+	Tempo         time.Time      // instante atual do jogo, fornecido pelo relógio da simulação
+	InfoReplay    string         // situação da reprodução de um replay (vazio fora de replays)
+	Cabecalho     CabecalhoMapa  // metadados e regras do mapa carregado
+	Campanha      InfoCampanha   // nível atual da campanha (vazio fora de campanhas)
+	IntroducaoAte time.Time      // fim da tela de apresentação do nível (zero se não há)
 }
 
+// Durações das mecânicas baseadas em tempo
+const (
+	DuracaoBomba    = 3 * time.Second        // tempo até a bomba explodir
+	DuracaoExplosao = 500 * time.Millisecond // tempo que a explosão fica ativa
+	IntervaloDano   = 2 * time.Second        // invulnerabilidade após receber dano
+
+	IntervaloPiscarCura = time.Second // tempo entre duas trocas de cor das curas
+)
+
+// Mochila de bombas padrão, que o cabeçalho do mapa pode alterar
+const (
+	CapacidadeBombasPadrao = 3               // bombas carregadas ao mesmo tempo
+	RecargaBombaPadrao     = 3 * time.Second // tempo para recuperar uma bomba usada
+)
+
+// Pontuação de cada conquista do jogador
+const (
+	PontosInimigo = 100 // inimigo eliminado
+	PontosCura    = 50  // cura coletada
+	PontosNivel   = 500 // nível de campanha concluído
+)
+
 // Elementos visuais do jogo
 var (
 	Personagem = Elemento{'☺', CorCinzaEscuro, CorPadrao, true}
@@ -75,74 +119,162 @@ func jogoNovo() Jogo {
 		Direcao:      'w',
 		StatusMsg:    "Jogo iniciado",
 		Entidades:    make([]Entidade, 0),
-		LogsInimigos: make([]string, 0),
+		ProximoID:    1,
+		LogsInimigos: make(map[int]string),
 		Vida:         3, // jogador começa com 3 corações
-
+		Cabecalho:    mapaCabecalhoPadrao(),
+		FormaBomba:   FormaLosango,
+		// Referência provisória: a simulação desloca o tempo para o seu
+		// relógio ao começar (veja jogoAjustarTempo)
+		Tempo: time.Unix(0, 0).UTC(),
 	}
 }
 
-func piscarcor(jogo *Jogo) {
-	pisca := false
-	for {
-		var novaCor Cor
-		if pisca {
-			novaCor = CorBranco
-		} else {
-			novaCor = CorVerde 
+// Desloca todos os instantes do jogo para que jogo.Tempo passe a ser agora
+// Usado quando a simulação começa, para que bombas, explosões, o intervalo
+// entre danos e os prazos dos inimigos e ninhos de um jogo carregado
+// continuem com o tempo que lhes restava
+func jogoAjustarTempo(jogo *Jogo, agora time.Time) {
+	delta := agora.Sub(jogo.Tempo)
+	for i := range jogo.Bombas {
+		jogo.Bombas[i].TempoVida = jogo.Bombas[i].TempoVida.Add(delta)
+	}
+	for i := range jogo.Explosoes {
+		jogo.Explosoes[i].TempoVida = jogo.Explosoes[i].TempoVida.Add(delta)
+	}
+	for i := range jogo.Chamas {
+		jogo.Chamas[i].Inicio = jogo.Chamas[i].Inicio.Add(delta)
+	}
+	for i := range jogo.Ninhos {
+		if !jogo.Ninhos[i].Proximo.IsZero() {
+			jogo.Ninhos[i].Proximo = jogo.Ninhos[i].Proximo.Add(delta)
 		}
-		pisca = !pisca
-
-		// Adquire o lock para modificar o mapa
-		mutexChan <- struct{}{}
-		curaEncontrada := false
-		for y := range jogo.Mapa {
-			for x := range jogo.Mapa[y] {
-				if jogo.Mapa[y][x].simbolo == Cura.simbolo {
-					jogo.Mapa[y][x].cor = novaCor
-					curaEncontrada = true
-				}
+	}
+	if !jogo.UltimoDano.IsZero() {
+		jogo.UltimoDano = jogo.UltimoDano.Add(delta)
+	}
+	if !jogo.RecargaDesde.IsZero() {
+		jogo.RecargaDesde = jogo.RecargaDesde.Add(delta)
+	}
+	if !jogo.IntroducaoAte.IsZero() {
+		jogo.IntroducaoAte = jogo.IntroducaoAte.Add(delta)
+	}
+	for i := range jogo.Entidades {
+		mente := &jogo.Entidades[i].Mente
+		for _, instante := range []*time.Time{&mente.Prazo, &mente.ProximoDisparo, &mente.ProximoAtaque} {
+			if !instante.IsZero() {
+				*instante = instante.Add(delta)
 			}
 		}
-		<-mutexChan // Libera o lock
+	}
+	jogo.Tempo = agora
+}
 
-		// Se não há mais curas no mapa, para de piscar
-		if !curaEncontrada {
-			return
-		}
+// Cria um jogo vazio que continua a partida anterior em um novo mapa
+// Mantém vida (ou a restaura, se o jogador tinha perdido), pontuação,
+// campanha, relógio e o próximo ID livre, para que as novas entidades nunca
+// reutilizem o ID de uma goroutine antiga
+func jogoContinuar(anterior *Jogo) Jogo {
+	jogo := jogoNovo()
+	jogo.ProximoID = anterior.ProximoID
+	if anterior.Vida > 0 {
+		jogo.Vida = anterior.Vida
+	}
+	jogo.Pontos = anterior.Pontos
+	jogo.Campanha = anterior.Campanha
+	jogo.Tempo = anterior.Tempo
+	jogo.InfoReplay = anterior.InfoReplay
+	return jogo
+}
+
+// Indica se a tela de apresentação do nível ainda está sendo exibida
+// Durante a apresentação o jogo fica parado
+func jogoEmIntroducao(jogo *Jogo) bool {
+	return jogo.Tempo.Before(jogo.IntroducaoAte)
+}
+
+// Cria uma cópia independente do estado do jogo
+// A cópia é usada como quadro imutável pelo renderizador e pelos inimigos,
+// que nunca acessam o Jogo mantido pela goroutine da simulação
+func jogoCopiar(jogo *Jogo) Jogo {
+	copia := *jogo
+
+	copia.Mapa = make([][]Elemento, len(jogo.Mapa))
+	for y, linha := range jogo.Mapa {
+		copia.Mapa[y] = append([]Elemento(nil), linha...)
+	}
+	copia.Entidades = append([]Entidade(nil), jogo.Entidades...)
+	copia.LogsInimigos = make(map[int]string, len(jogo.LogsInimigos))
+	for id, log := range jogo.LogsInimigos {
+		copia.LogsInimigos[id] = log
+	}
+	copia.Bombas = append([]Bomba(nil), jogo.Bombas...)
+	copia.Explosoes = append([]Explosao(nil), jogo.Explosoes...)
+	copia.Projeteis = append([]Projetil(nil), jogo.Projeteis...)
+	copia.Ninhos = append([]Ninho(nil), jogo.Ninhos...)
+	copia.Chamas = append([]Chama(nil), jogo.Chamas...)
 
-		interfaceDesenharJogo(jogo)
-		time.Sleep(1000 * time.Millisecond)
+	return copia
+}
+
+// Faz as curas do mapa piscarem, alternando entre verde e branco
+// A cor depende apenas de jogo.Tempo, para que partidas com semente gerem
+// sempre os mesmos quadros, e vale também para as curas de um novo nível
+func jogoPiscarCuras(jogo *Jogo) {
+	novaCor := CorVerde
+	if (jogo.Tempo.UnixNano()/int64(IntervaloPiscarCura))%2 == 1 {
+		novaCor = CorBranco
+	}
+	for y := range jogo.Mapa {
+		for x := range jogo.Mapa[y] {
+			if jogo.Mapa[y][x].simbolo == Cura.simbolo {
+				jogo.Mapa[y][x].cor = novaCor
+			}
+		}
 	}
 }
 
-// Lê um arquivo texto linha por linha e constrói o mapa do jogo
+// Lê um arquivo de mapa (veja mapaLer) e constrói o mapa do jogo
+// Cada caractere da grade vira o elemento indicado pela legenda. Mapas que
+// não passam em mapaValidar são recusados com a lista de problemas
 func jogoCarregarMapa(nome string, jogo *Jogo) error {
-	arq, err := os.Open(nome)
+	arq, err := mapaLer(nome)
 	if err != nil {
 		return err
 	}
-	defer arq.Close()
+	if err := mapaValidar(nome, arq); err != nil {
+		return err
+	}
 
-	scanner := bufio.NewScanner(arq)
-	y := 0
-	for scanner.Scan() {
-		linha := scanner.Text()
+	jogoMontarMapa(arq, jogo)
+	return nil
+}
+
+// Constrói o mapa e as entidades do jogo a partir de um mapa já validado
+func jogoMontarMapa(arq *ArquivoMapa, jogo *Jogo) {
+	jogo.Cabecalho = arq.Cabecalho
+	jogo.FormaBomba = arq.Cabecalho.FormaBomba
+	jogo.BombasMochila = arq.Cabecalho.CapacidadeBombas // Cada nível começa com a mochila cheia
+	jogo.RecargaDesde = time.Time{}
+	if jogo.Vida > jogo.Cabecalho.VidaMaxima {
+		jogo.Vida = jogo.Cabecalho.VidaMaxima
+	}
+
+	for y, linha := range arq.Linhas {
 		var linhaElems []Elemento
-		for x, ch := range linha {
-			e := Vazio
-			switch ch {
-			case Cura.simbolo:
-				e = Cura
-			case Parede.simbolo:
-				e = Parede
-			case Inimigo.simbolo:
-				ent := Entidade{X: x, Y: y, UltimoVisitado: e, Sprite: Inimigo}
+		for x, ch := range []rune(linha) {
+			tipo := arq.Legenda[ch]
+			e, _ := mapaElementoDoTipo(tipo)
+			if arquetipo, ok := arquetipoBuscar(tipo); ok {
+				ent := Entidade{ID: jogoNovoID(jogo), X: x, Y: y, UltimoVisitado: Vazio, Sprite: arquetipo.Sprite, Tipo: tipo, Vida: arquetipo.Vida}
 				jogo.Entidades = append(jogo.Entidades, ent) // Adiciona inimigo
 				e = Vazio
-			case Vegetacao.simbolo:
-				e = Vegetacao
-			case Personagem.simbolo:
-				ent := Entidade{X: x, Y: y, UltimoVisitado: e, Sprite: Personagem}
+			}
+			switch tipo {
+			case TipoNinho:
+				jogo.Ninhos = append(jogo.Ninhos, Ninho{ID: jogoNovoID(jogo), X: x, Y: y})
+			case TipoPersonagem:
+				ent := Entidade{ID: jogoNovoID(jogo), X: x, Y: y, UltimoVisitado: Vazio, Sprite: Personagem}
 				jogo.Entidades = append([]Entidade{ent}, jogo.Entidades...) // Adiciona personagem no início
 				e = Vazio
 				// O personagem é o primeiro elemento em jogo.Entidades[0]
@@ -151,12 +283,71 @@ func jogoCarregarMapa(nome string, jogo *Jogo) error {
 			linhaElems = append(linhaElems, e)
 		}
 		jogo.Mapa = append(jogo.Mapa, linhaElems)
-		y++
 	}
-	if err := scanner.Err(); err != nil {
-		return err
+
+	// Inicializa logs para cada inimigo (exceto o personagem que é índice 0)
+	// e entrega cada rota ao inimigo que está no seu primeiro ponto
+	for i, ent := range jogo.Entidades[1:] {
+		jogo.LogsInimigos[ent.ID] = "Aguardando..."
+		for _, rota := range arq.Rotas {
+			if rota.Pontos[0] == (Ponto{ent.X, ent.Y}) {
+				jogo.Entidades[i+1].Rota = &rota.Rota
+			}
+		}
 	}
-	return nil
+}
+
+// ============================================================================
+// MÓDULO DE IDENTIFICAÇÃO DE ENTIDADES
+// ============================================================================
+
+// Reserva um novo identificador único para uma entidade
+func jogoNovoID(jogo *Jogo) int {
+	id := jogo.ProximoID
+	jogo.ProximoID++
+	return id
+}
+
+// Procura a entidade com o ID informado
+// Retorna seu índice em jogo.Entidades ou -1 se ela não existe mais
+func jogoBuscarEntidade(jogo *Jogo, id int) int {
+	for i, ent := range jogo.Entidades {
+		if ent.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// Remove a entidade do índice informado, junto com seu log
+func jogoRemoverEntidade(jogo *Jogo, i int) {
+	delete(jogo.LogsInimigos, jogo.Entidades[i].ID)
+	jogo.Entidades = append(jogo.Entidades[:i], jogo.Entidades[i+1:]...)
+}
+
+// Cria um inimigo do tipo informado na posição, gerado pelo ninho ou chefe
+// de ID origem; a simulação inicia sua goroutine no mesmo tick
+func jogoAdicionarInimigo(jogo *Jogo, tipo string, pos Ponto, origem int) {
+	arquetipo, _ := arquetipoBuscar(tipo)
+	ent := Entidade{
+		ID:             jogoNovoID(jogo),
+		Sprite:         arquetipo.Sprite,
+		X:              pos.X,
+		Y:              pos.Y,
+		UltimoVisitado: jogo.Mapa[pos.Y][pos.X],
+		Tipo:           arquetipo.Tipo,
+		Vida:           arquetipo.Vida,
+		Ninho:          origem,
+	}
+	jogo.Entidades = append(jogo.Entidades, ent)
+	jogo.LogsInimigos[ent.ID] = "Aguardando..."
+}
+
+// Indica se a entidade ocupa a célula (x, y)
+// Entidades grandes (como o chefe) ocupam um quadrado a partir de (X, Y)
+func jogoEntidadeOcupa(ent Entidade, x, y int) bool {
+	t := arquetipoTamanho(ent)
+	return x >= ent.X && x < ent.X+t && y >= ent.Y && y < ent.Y+t
 }
 
 // Verifica se uma entidade pode se mover para a posição (x, y)
@@ -181,22 +372,48 @@ func jogoPodeMoverPara(jogo *Jogo, x, y int) bool {
 }
 
 // Verifica se uma entidade pode se mover para a posição, excluindo o personagem
-func jogoPodeMoverParaInimigo(jogo *Jogo, x, y int, inimigoIdx int) bool {
-	// Verifica limites e tangibilidade
-	if !jogoPodeMoverPara(jogo, x, y) {
-		return false
+// Inimigos grandes precisam de todas as células do quadrado que ocupariam
+func jogoPodeMoverParaInimigo(jogo *Jogo, x, y int, inimigoID int) bool {
+	tamanho := 1
+	if idx := jogoBuscarEntidade(jogo, inimigoID); idx > 0 {
+		tamanho = arquetipoTamanho(jogo.Entidades[idx])
 	}
 
-	// Verifica se já existe outro inimigo nessa posição (mas permite posição do personagem)
-	for i, ent := range jogo.Entidades {
-		if i != 0 && i != inimigoIdx && ent.X == x && ent.Y == y {
-			return false // Bloqueia movimento para posição de outro inimigo
+	for cy := y; cy < y+tamanho; cy++ {
+		for cx := x; cx < x+tamanho; cx++ {
+			// Verifica limites e tangibilidade (fantasmas atravessam paredes)
+			if !jogoPodeMoverPara(jogo, cx, cy) && !jogoFantasmaAtravessa(jogo, cx, cy, inimigoID) {
+				return false
+			}
+
+			// Verifica se já existe outro inimigo nessa posição (mas permite posição do personagem)
+			for i, ent := range jogo.Entidades {
+				if i != 0 && ent.ID != inimigoID && jogoEntidadeOcupa(ent, cx, cy) {
+					return false // Bloqueia movimento para posição de outro inimigo
+				}
+			}
 		}
 	}
 
 	return true // Permite movimento para posição do personagem ou vazia
 }
 
+// Verifica se o inimigo atravessa a parede da posição (x, y)
+// Só inimigos do tipo fantasma atravessam, tanto paredes quanto paredes
+// frágeis, e nunca para fora do mapa
+func jogoFantasmaAtravessa(jogo *Jogo, x, y int, inimigoID int) bool {
+	if y < 0 || y >= len(jogo.Mapa) || x < 0 || x >= len(jogo.Mapa[y]) || !jogoEhParede(jogo.Mapa[y][x]) {
+		return false
+	}
+	idx := jogoBuscarEntidade(jogo, inimigoID)
+	return idx > 0 && arquetipoDe(jogo.Entidades[idx]).Atravessa
+}
+
+// Indica se o elemento é uma parede, frágil ou não
+func jogoEhParede(elem Elemento) bool {
+	return elem.simbolo == Parede.simbolo || elem.simbolo == ParedeFragil.simbolo
+}
+
 // Verifica se o personagem pode se mover para a posição (bloqueia movimento para inimigos)
 func jogoPodeMoverParaPersonagem(jogo *Jogo, x, y int) bool {
 	// Verifica limites e tangibilidade
@@ -206,7 +423,7 @@ func jogoPodeMoverParaPersonagem(jogo *Jogo, x, y int) bool {
 
 	// Verifica se já existe algum inimigo nessa posição
 	for i, ent := range jogo.Entidades {
-		if i != 0 && ent.X == x && ent.Y == y {
+		if i != 0 && jogoEntidadeOcupa(ent, x, y) {
 			return false // Bloqueia movimento para posição de inimigo
 		}
 	}
@@ -214,12 +431,21 @@ func jogoPodeMoverParaPersonagem(jogo *Jogo, x, y int) bool {
 	return true
 }
 
+// Troca o elemento da célula (x, y) do mapa
+// As entidades sobre a célula passam a guardar o novo elemento, para não
+// devolverem o antigo ao mapa quando saírem dela
+func jogoTrocarCelula(jogo *Jogo, x, y int, elem Elemento) {
+	jogo.Mapa[y][x] = elem
+	jogo.VersaoMapa++
+	for i := range jogo.Entidades {
+		if jogo.Entidades[i].X == x && jogo.Entidades[i].Y == y {
+			jogo.Entidades[i].UltimoVisitado = elem
+		}
+	}
+}
+
 // Move um elemento para a nova posição
 func jogoMoverElemento(jogo *Jogo, x, y, dx, dy int, ent *Entidade) {
-	// Adquire o lock usando canal (semáforo binário)
-	mutexChan <- struct{}{}
-	defer func() { <-mutexChan }() // Libera o lock
-
 	// Calcula nova posição
 	nx, ny := x+dx, y+dy
 	jogo.Mapa[y][x] = ent.UltimoVisitado
@@ -229,6 +455,39 @@ func jogoMoverElemento(jogo *Jogo, x, y, dx, dy int, ent *Entidade) {
 	ent.X, ent.Y = nx, ny
 }
 
+// ============================================================================
+// MÓDULO DE SISTEMA DE VIDA
+// ============================================================================
+
+// Altera a vida do jogador (valor positivo cura, negativo causa dano)
+// O dano respeita um intervalo de 2 segundos entre danos
+func jogoAlterarVida(jogo *Jogo, v int) {
+	if v < 0 {
+		// Cooldown de 2 segundos entre danos
+		agora := jogo.Tempo
+		if agora.Sub(jogo.UltimoDano) < IntervaloDano || jogo.Vida <= 0 {
+			return
+		}
+		// Atualiza tempo do último dano
+		jogo.UltimoDano = agora
+	}
+
+	jogo.Vida += v
+	if jogo.Vida > jogo.Cabecalho.VidaMaxima {
+		jogo.Vida = jogo.Cabecalho.VidaMaxima // Limita vida máxima
+	}
+	if jogo.Vida < 0 {
+		jogo.Vida = 0 // Limita vida mínima
+	}
+
+	// Atualiza mensagem baseada na mudança
+	if v > 0 {
+		jogo.StatusMsg = "Vida aumentada!"
+	} else if v < 0 {
+		jogo.StatusMsg = "Dano recebido!"
+	}
+}
+
 // ============================================================================
 // MÓDULO DE SISTEMA DE BOMBAS
 // ============================================================================
@@ -244,98 +503,201 @@ func jogoColocarBomba(jogo *Jogo) {
 			return
 		}
 	}
+
+	// Verifica se ainda há bombas na mochila
+	if jogo.BombasMochila <= 0 {
+		jogo.StatusMsg = "Sem bombas! Aguarde a recarga"
+		return
+	}
+	jogo.BombasMochila--
+	if jogo.RecargaDesde.IsZero() {
+		jogo.RecargaDesde = jogo.Tempo // A mochila estava cheia: começa a recarga
+	}
 	
 	// Cria nova bomba
 	novaBomba := Bomba{
 		X:         x,
 		Y:         y,
-		TempoVida: time.Now(),
+		TempoVida: jogo.Tempo,
 		Ativa:     true,
+		Forma:     jogo.FormaBomba,
 	}
 	
 	jogo.Bombas = append(jogo.Bombas, novaBomba)
 	jogo.StatusMsg = "Bomba colocada!"
 }
 
+// Devolve à mochila as bombas cuja recarga terminou
+// As bombas são recuperadas uma de cada vez, a cada RecargaBomba do mapa,
+// até a mochila encher
+func jogoRecarregarBombas(jogo *Jogo) {
+	for !jogo.RecargaDesde.IsZero() && jogo.Tempo.Sub(jogo.RecargaDesde) >= jogo.Cabecalho.RecargaBomba {
+		jogo.BombasMochila++
+		jogo.RecargaDesde = jogo.RecargaDesde.Add(jogo.Cabecalho.RecargaBomba)
+		if jogo.BombasMochila >= jogo.Cabecalho.CapacidadeBombas {
+			jogo.BombasMochila = jogo.Cabecalho.CapacidadeBombas
+			jogo.RecargaDesde = time.Time{}
+		}
+	}
+}
+
 // Atualiza o estado das bombas (verifica se devem explodir)
-func jogoAtualizarBombas(jogo *Jogo) {
-	tempoAtual := time.Now()
+// Cada bomba que explode pode detonar outras (veja jogoReacaoEmCadeia).
+// Retorna a posição de todas as bombas que explodiram
+func jogoAtualizarBombas(jogo *Jogo) []Ponto {
+	tempoAtual := jogo.Tempo
+	var vencidas []Bomba
 	
 	for i := len(jogo.Bombas) - 1; i >= 0; i-- {
 		bomba := &jogo.Bombas[i]
 		
-		if bomba.Ativa && tempoAtual.Sub(bomba.TempoVida) >= 3*time.Second {
+		if bomba.Ativa && tempoAtual.Sub(bomba.TempoVida) >= DuracaoBomba {
 			// Bomba explode após 3 segundos
-			jogoExplodirBomba(jogo, bomba.X, bomba.Y)
+			vencidas = append(vencidas, *bomba)
 			bomba.Ativa = false
 			
 			// Remove bomba da lista
 			jogo.Bombas = append(jogo.Bombas[:i], jogo.Bombas[i+1:]...)
 		}
 	}
+	
+	var explodidas []Ponto
+	if len(vencidas) > 0 {
+		// A mensagem vem antes das explosões, para não esconder a de um
+		// inimigo ou do chefe atingido por elas
+		jogo.Cadeia = 0
+		jogo.StatusMsg = "BOOM! Bomba explodiu!"
+	}
+	for _, bomba := range vencidas {
+		explodidas = append(explodidas, jogoReacaoEmCadeia(jogo, bomba)...)
+	}
+	return explodidas
 }
 
-// Cria uma explosão na posição especificada com raio 5
-func jogoExplodirBomba(jogo *Jogo, x, y int) {
-	raio := 5
-	tempoAtual := time.Now()
+// Explode a bomba informada e, em seguida, cada bomba alcançada pelas
+// explosões, no mesmo passo
+// As bombas alcançadas entram em uma fila em vez de explodirem por
+// recursão, para que cadeias longas não aprofundem a pilha. O aviso da
+// cadeia só substitui o da bomba, não o de um inimigo atingido. Retorna a
+// posição das bombas da cadeia, na ordem em que explodiram
+func jogoReacaoEmCadeia(jogo *Jogo, inicio Bomba) []Ponto {
+	fila := []Bomba{inicio}
+	var posicoes []Ponto
+	for i := 0; i < len(fila); i++ {
+		fila = append(fila, jogoExplodirBomba(jogo, fila[i])...)
+		posicoes = append(posicoes, Ponto{fila[i].X, fila[i].Y})
+	}
 	
-	// Cria explosões em todas as direções dentro do raio
-	for dx := -raio; dx <= raio; dx++ {
-		for dy := -raio; dy <= raio; dy++ {
-			// Calcula distância Manhattan (mais apropriada para jogos em grade)
-			distancia := abs(dx) + abs(dy)
-			if distancia <= raio {
-				ex, ey := x+dx, y+dy
-				
-				// Verifica se a posição está dentro dos limites do mapa
-				if ex >= 0 && ex < len(jogo.Mapa[0]) && ey >= 0 && ey < len(jogo.Mapa) {
-					// Não explode através de paredes
-					if jogo.Mapa[ey][ex].simbolo != Parede.simbolo {
-						explosao := Explosao{
-							X:         ex,
-							Y:         ey,
-							TempoVida: tempoAtual,
-							Ativa:     true,
-						}
-						jogo.Explosoes = append(jogo.Explosoes, explosao)
-						
-						// Verifica se há inimigos na posição da explosão
-						jogoVerificarInimigoNaExplosao(jogo, ex, ey)
-					}
-				}
-			}
+	if len(fila) > jogo.Cadeia {
+		jogo.Cadeia = len(fila)
+	}
+	if len(fila) > 1 && strings.HasPrefix(jogo.StatusMsg, "BOOM!") {
+		jogo.StatusMsg = fmt.Sprintf("BOOM! Reação em cadeia de %d bombas!", len(fila))
+	}
+	return posicoes
+}
+
+// Cria a explosão da bomba com a sua forma e o raio definido pelo mapa
+// Retorna as bombas alcançadas pela explosão, que são retiradas de
+// jogo.Bombas e devem explodir em seguida
+func jogoExplodirBomba(jogo *Jogo, bomba Bomba) []Bomba {
+	tempoAtual := jogo.Tempo
+	var alcancadas []Bomba
+	chefe, fase := -1, FaseInvestida
+	if i := jogoBuscarChefe(jogo); i >= 0 {
+		chefe, fase = jogo.Entidades[i].ID, chefeFase(jogo.Entidades[i])
+	}
+	
+	// Cria explosões nas células atingidas (veja explosaoCelulas)
+	for _, p := range explosaoCelulas(jogo, bomba.X, bomba.Y, bomba.Forma, jogo.Cabecalho.RaioBomba) {
+		explosao := Explosao{
+			X:         p.X,
+			Y:         p.Y,
+			TempoVida: tempoAtual,
+			Ativa:     true,
+		}
+		jogo.Explosoes = append(jogo.Explosoes, explosao)
+		
+		// Verifica se há inimigos ou ninhos na posição da explosão
+		jogoVerificarInimigoNaExplosao(jogo, p.X, p.Y)
+		jogoVerificarNinhoNaExplosao(jogo, p.X, p.Y)
+		
+		// Derruba paredes frágeis e põe fogo na vegetação
+		jogoVerificarParedeNaExplosao(jogo, p.X, p.Y)
+		jogoAcenderFogo(jogo, p.X, p.Y)
+		
+		// Bombas alcançadas explodem em cadeia
+		if alcancada, ok := jogoDetonarBomba(jogo, p.X, p.Y); ok {
+			alcancadas = append(alcancadas, alcancada)
 		}
 	}
 	
-	jogo.StatusMsg = "BOOM! Bomba explodiu!"
+	// A mudança de fase do chefe prevalece sobre o aviso das células dele
+	// atingidas depois dela
+	if i := jogoBuscarEntidade(jogo, chefe); chefe >= 0 && i >= 0 && chefeFase(jogo.Entidades[i]) != fase {
+		jogo.StatusMsg = fmt.Sprintf("O chefe mudou de fase: %v!", chefeFase(jogo.Entidades[i]))
+	}
+	return alcancadas
+}
+
+// Retira de jogo.Bombas a bomba ativa da posição (x, y), se houver
+// Retorna a bomba e true se havia uma, que deve então explodir
+func jogoDetonarBomba(jogo *Jogo, x, y int) (Bomba, bool) {
+	for i, bomba := range jogo.Bombas {
+		if bomba.X == x && bomba.Y == y && bomba.Ativa {
+			jogo.Bombas = append(jogo.Bombas[:i], jogo.Bombas[i+1:]...)
+			return bomba, true
+		}
+	}
+	return Bomba{}, false
 }
 
 // Verifica se há inimigos na posição da explosão e os elimina
+// Como a função é chamada para cada célula da explosão, o chefe perde uma
+// vida por célula atingida que ele ocupa. O personagem é ferido à parte,
+// pela goroutine das explosões (veja explosaoExecutar)
 func jogoVerificarInimigoNaExplosao(jogo *Jogo, x, y int) {
+	jogoAtingirInimigos(jogo, x, y, "pela explosão")
+}
+
+// Tira uma vida dos inimigos que ocupam a posição (x, y)
+// Inimigos resistentes (como o tanque) só são eliminados quando a vida
+// acaba. A causa completa a mensagem de status ("pela explosão")
+func jogoAtingirInimigos(jogo *Jogo, x, y int, causa string) {
 	for i := len(jogo.Entidades) - 1; i >= 1; i-- { // Começa do 1 para não afetar o jogador
-		if jogo.Entidades[i].X == x && jogo.Entidades[i].Y == y {
-			// Remove inimigo
-			jogo.Entidades = append(jogo.Entidades[:i], jogo.Entidades[i+1:]...)
-			
-			// Remove log correspondente
-			if i-1 < len(jogo.LogsInimigos) {
-				jogo.LogsInimigos = append(jogo.LogsInimigos[:i-1], jogo.LogsInimigos[i:]...)
+		if jogoEntidadeOcupa(jogo.Entidades[i], x, y) {
+			ent := &jogo.Entidades[i]
+			arquetipo := arquetipoDe(*ent)
+			fase := chefeFase(*ent)
+			if ent.Vida--; ent.Vida > 0 {
+				jogo.StatusMsg = fmt.Sprintf("%s atingido! Resta %d de vida", arquetipo.Rotulo, ent.Vida)
+				if arquetipo.Chefe && chefeFase(*ent) != fase {
+					jogo.StatusMsg = fmt.Sprintf("O chefe mudou de fase: %v!", chefeFase(*ent))
+				}
+				continue
+			}
+
+			// Remove inimigo e seu log (a simulação encerra sua goroutine)
+			jogoRemoverEntidade(jogo, i)
+			if arquetipo.Chefe {
+				jogo.Pontos += PontosChefe
+			} else {
+				jogo.Pontos += PontosInimigo
 			}
 			
-			jogo.StatusMsg = "Inimigo eliminado pela explosão!"
+			jogo.StatusMsg = "Inimigo eliminado " + causa + "!"
 		}
 	}
 }
 
 // Atualiza o estado das explosões (remove as que expiraram)
 func jogoAtualizarExplosoes(jogo *Jogo) {
-	tempoAtual := time.Now()
+	tempoAtual := jogo.Tempo
 	
 	for i := len(jogo.Explosoes) - 1; i >= 0; i-- {
 		explosao := &jogo.Explosoes[i]
 		
-		if explosao.Ativa && tempoAtual.Sub(explosao.TempoVida) >= 500*time.Millisecond {
+		if explosao.Ativa && tempoAtual.Sub(explosao.TempoVida) >= DuracaoExplosao {
 			// Explosão dura 500ms
 			explosao.Ativa = false
 			
@@ -357,17 +719,35 @@ func abs(x int) int {
 // MÓDULO DE SISTEMA DE VITÓRIA E DERROTA
 // ============================================================================
 
-// Verifica e processa condição de vitória (não há mais inimigos no mapa)
+// Verifica e processa condição de vitória definida pelo mapa
+// (por padrão, não há mais inimigos nem ninhos no mapa)
 func jogoVerificarVitoria(jogo *Jogo) {
 	// Só verifica se o jogo ainda não terminou
 	if jogo.JogoTerminado {
 		return
 	}
 	
+	if jogo.Cabecalho.Vitoria == VitoriaCuras {
+		// Procura alguma cura que ainda não foi coletada
+		for _, linha := range jogo.Mapa {
+			for _, elem := range linha {
+				if elem.simbolo == Cura.simbolo {
+					return
+				}
+			}
+		}
+		jogo.JogoTerminado = true
+		jogo.Venceu = true
+		jogo.StatusMsg = "VITORIA! Todas as curas foram coletadas!"
+		return
+	}
+	
 	// Conta quantos inimigos restam (excluindo o jogador que está no índice 0)
+	// Enquanto houver ninhos, novos inimigos podem surgir
 	numInimigos := len(jogo.Entidades) - 1
-	if numInimigos <= 0 {
+	if numInimigos <= 0 && len(jogo.Ninhos) == 0 {
 		jogo.JogoTerminado = true
+		jogo.Venceu = true
 		jogo.StatusMsg = "VITORIA! Todos os inimigos foram eliminados!"
 	}
 }
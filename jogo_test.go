@@ -0,0 +1,49 @@
+// jogo_test.go - Testes da mochila de bombas
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// Com a mochila vazia não se coloca bomba; cada bomba volta depois de uma
+// recarga, contada pelo relógio simulado, até a mochila encher
+func TestMochilaDeBombas(t *testing.T) {
+	jogo := testeCarregarMapa(t, "testdata/cadeia.txt")
+	relogio := relogioSimuladoNovo()
+	jogo.Tempo = relogio.Agora()
+	capacidade, recarga := jogo.Cabecalho.CapacidadeBombas, jogo.Cabecalho.RecargaBomba
+
+	for i := 0; i < capacidade; i++ {
+		jogo.Entidades[0].X = 1 + i
+		jogoColocarBomba(&jogo)
+	}
+	jogo.Entidades[0].X = 1 + capacidade
+	jogoColocarBomba(&jogo)
+	if jogo.BombasMochila != 0 || len(jogo.Bombas) != capacidade || jogo.StatusMsg != "Sem bombas! Aguarde a recarga" {
+		t.Fatalf("mochila vazia: %d na mochila, %d no mapa, mensagem %q", jogo.BombasMochila, len(jogo.Bombas), jogo.StatusMsg)
+	}
+
+	passos := []struct {
+		avanco  time.Duration
+		mochila int
+	}{
+		{recarga - time.Millisecond, 0},
+		{time.Millisecond, 1},
+		{recarga, 2},
+		{time.Duration(capacidade) * recarga, capacidade},
+	}
+	var decorrido time.Duration
+	for _, passo := range passos {
+		decorrido += passo.avanco
+		relogio.Avancar(passo.avanco)
+		jogo.Tempo = relogio.Agora()
+		jogoRecarregarBombas(&jogo)
+		if jogo.BombasMochila != passo.mochila {
+			t.Errorf("depois de %v: %d na mochila, esperado %d", decorrido, jogo.BombasMochila, passo.mochila)
+		}
+	}
+	if !jogo.RecargaDesde.IsZero() {
+		t.Errorf("mochila cheia continua recarregando desde %v", jogo.RecargaDesde)
+	}
+}
@@ -2,101 +2,314 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"os"
-	"time"
+	"strings"
+)
+
+// Versão do jogo, registrada nos replays gravados
+// Deve mudar a cada alteração que muda o andamento de uma partida (inimigos,
+// bombas, regras do mapa), pois replays antigos deixam de ser reproduzidos
+// igual e a reprodução avisa quando as versões diferem
+const VersaoJogo = "1.2.9"
+
+// Tamanho da tela em memória usada no modo sem terminal (-headless)
+const (
+	LarguraTelaMemoria = 100
+	AlturaTelaMemoria  = 50
 )
 
 func main() {
-	// Inicializa a interface (termbox)
-	interfaceIniciar()
-	defer interfaceFinalizar()
+	// Subcomandos: "jogo replay <arquivo>" reproduz uma partida gravada,
+	// "jogo validate <mapa>..." verifica arquivos de mapa e "jogo generate"
+	// cria um mapa aleatório
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "replay":
+			executarReplay(os.Args[2:])
+			return
+		case "validate":
+			executarValidacao(os.Args[2:])
+			return
+		case "generate":
+			executarGeracao(os.Args[2:])
+			return
+		}
+	}
+	executarJogo(os.Args[1:])
+}
+
+// Gera um mapa aleatório e o grava em um arquivo ou na saída padrão
+func executarGeracao(args []string) {
+	padrao := geradorConfigPadrao()
+	flags := flag.NewFlagSet("jogo generate", flag.ExitOnError)
+	algoritmo := flags.String("algoritmo", padrao.Algoritmo, "algoritmo de geração: "+strings.Join(AlgoritmosGerador, ", "))
+	largura := flags.Int("largura", padrao.Largura, "largura do mapa, incluindo as bordas")
+	altura := flags.Int("altura", padrao.Altura, "altura do mapa, incluindo as bordas")
+	inimigos := flags.Int("inimigos", padrao.Inimigos, "quantidade de inimigos")
+	curas := flags.Int("curas", padrao.Curas, "quantidade de curas")
+	vegetacao := flags.Float64("vegetacao", padrao.Vegetacao, "fração das células livres coberta de vegetação (0 a 1)")
+	semente := flags.Uint64("semente", 0, "semente do gerador; a mesma semente gera o mesmo mapa (0 = aleatória)")
+	saida := flags.String("saida", "", "arquivo onde gravar o mapa (padrão: saída padrão)")
+	flags.Parse(args)
+
+	config := ConfigGerador{
+		Algoritmo: *algoritmo,
+		Largura:   *largura,
+		Altura:    *altura,
+		Inimigos:  *inimigos,
+		Curas:     *curas,
+		Vegetacao: *vegetacao,
+		Semente:   *semente,
+	}
+	if config.Semente == 0 {
+		config.Semente = simulacaoSementeAleatoria()
+	}
+
+	linhas, err := geradorGerar(config)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	texto := strings.Join(linhas, "\n") + "\n"
+	if *saida == "" {
+		fmt.Print(texto)
+		return
+	}
+	if err := os.WriteFile(*saida, []byte(texto), 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// Verifica os mapas informados e lista os problemas de cada um
+// Termina com código 1 se algum mapa for inválido
+func executarValidacao(args []string) {
+	flags := flag.NewFlagSet("jogo validate", flag.ExitOnError)
+	flags.Parse(args)
+	if flags.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "uso: jogo validate <mapa>...")
+		os.Exit(2)
+	}
+
+	invalido := false
+	for _, nome := range flags.Args() {
+		arq, err := mapaLer(nome)
+		if err == nil {
+			err = mapaValidar(nome, arq)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			invalido = true
+			continue
+		}
+		fmt.Printf("%s: ok\n", nome)
+	}
+	if invalido {
+		os.Exit(1)
+	}
+}
+
+// Joga uma partida normal, com teclado ou roteiro de entrada
+func executarJogo(args []string) {
+	flags := flag.NewFlagSet("jogo", flag.ExitOnError)
+	// Intervalo entre os passos da simulação (configurável por linha de comando)
+	intervaloTick := flags.Duration("tick", IntervaloTickPadrao, "intervalo entre os passos da simulação")
+	roteiro := flags.String("roteiro", "", "arquivo com eventos de teclado programados (substitui o teclado)")
+	semente := flags.Uint64("semente", 0, "semente da partida; com ela, o mesmo roteiro gera sempre a mesma partida (0 = aleatória)")
+	gravar := flags.String("gravar", "", "grava a partida em um arquivo de replay")
+	salvar := flags.String("save", ArquivoSalvamentoPadrao, "arquivo onde a tecla F5 salva o jogo")
+	carregar := flags.String("load", "", "continua um jogo salvo com F5 em vez de começar pelo mapa")
+	campanha := flags.String("campanha", "", "joga em ordem os mapas de um manifesto de campanha")
+	semTela := flags.Bool("headless", false, "desenha em memória, sem terminal (exige -roteiro); imprime o quadro final")
+	flags.Parse(args)
 
 	// Usa "mapa.txt" como arquivo padrão ou lê o primeiro argumento
 	mapaFile := "mapa.txt"
-	if len(os.Args) > 1 {
-		mapaFile = os.Args[1]
+	if flags.NArg() > 0 {
+		mapaFile = flags.Arg(0)
 	}
 
-	// Inicializa o jogo
-	jogo := jogoNovo()
-	if err := jogoCarregarMapa(mapaFile, &jogo); err != nil {
-		panic(err)
-	}
-
-	// Inicializa logs para cada inimigo (exceto o personagem que é índice 0)
-	jogo.LogsInimigos = make([]string, len(jogo.Entidades)-1)
-	for i := range jogo.LogsInimigos {
-		jogo.LogsInimigos[i] = "Aguardando..."
-	}
-
-	chanVida := make(chan int) // Canal para gerenciar vida do personagem
-	go func() {
-		for {
-			select {
-			case v := <-chanVida:
-				jogo.Vida += v
-				if jogo.Vida > 5 {
-					jogo.Vida = 5 // Limita vida máxima
-				}
-				if jogo.Vida < 0 {
-					jogo.Vida = 0 // Limita vida mínima
-				}
-				
-				// Atualiza mensagem baseada na mudança
-				if v > 0 {
-					jogo.StatusMsg = "Vida aumentada!"
-				} else if v < 0 {
-					jogo.StatusMsg = "Dano recebido!"
-				}
-			}
+	if *semTela && *roteiro == "" {
+		fmt.Fprintln(os.Stderr, "a opção -headless exige um roteiro de entrada (-roteiro)")
+		os.Exit(2)
+	}
+
+	// Escolhe a fonte de entrada: roteiro em arquivo ou teclado
+	var entrada FonteEntrada
+	if *roteiro != "" {
+		fonte, err := fonteRoteiroCarregar(*roteiro)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
 		}
-	}()
-
-	canais := make([]chan [2]int, len(jogo.Entidades)-1) // Canais para inimigos detectarem o personagem
-	for i := range canais {
-		canais[i] = make(chan [2]int, 1)
-		go func(idx int, ch <-chan [2]int) { //Goroutine para cada inimigo agir independentemente
-			for {
-				inimigoExecutarAcao(&jogo, idx+1, ch, chanVida) // +1 porque o personagem é o índice 0
-				time.Sleep(500 * time.Millisecond)              //Velocidade do inimigo
-			}
-		}(i, canais[i])
-	}
-
-	go func() { //Envia a posição do personagem para os inimigos periodicamente
-		for {
-			for _, ch := range canais {
-				select {
-				case ch <- [2]int{jogo.Entidades[0].X, jogo.Entidades[0].Y}:
-					//jogo.StatusMsg = "Posição do personagem enviada para inimigos"
-				default:
-					//jogo.StatusMsg = "Posição do personagem não enviada"
-				}
-			}
-			time.Sleep(500 * time.Millisecond) //Intervalo de detecção do personagem
+		entrada = fonte
+	}
+
+	// Com uma semente informada, a simulação usa relógio simulado e é reproduzível
+	// Gravar um replay também exige uma partida reproduzível
+	config := ConfigSimulacao{Intervalo: *intervaloTick, Semente: *semente, Deterministico: *semente != 0 || *gravar != ""}
+	if config.Semente == 0 {
+		config.Semente = simulacaoSementeAleatoria()
+	}
+	if *gravar != "" {
+		config.Gravacao = replayNovo(mapaFile, *carregar, config.Semente, config.Intervalo)
+		config.Gravacao.Campanha = *campanha
+	}
+	config.Salvamento = *salvar
+
+	jogo, err := carregarPartida(mapaFile, *campanha, *carregar, &config)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	executarPartida(jogo, config, entrada, *semTela, nil)
+
+	if config.Gravacao != nil {
+		if err := replaySalvar(config.Gravacao, *gravar); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
 		}
-	}()
+	}
+}
+
+// Reproduz uma partida gravada com "-gravar"
+func executarReplay(args []string) {
+	flags := flag.NewFlagSet("replay", flag.ExitOnError)
+	semTela := flags.Bool("headless", false, "reproduz sem terminal e imprime o quadro final")
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "uso: jogo replay [-headless] <arquivo>")
+		os.Exit(2)
+	}
+
+	replay, err := replayCarregar(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if replay.Versao != VersaoJogo {
+		fmt.Fprintf(os.Stderr, "aviso: replay gravado na versão %s (atual: %s); a reprodução pode divergir\n", replay.Versao, VersaoJogo)
+	}
+
+	tick, err := replayTick(replay)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	roteiro, err := replayRoteiro(replay)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	config := ConfigSimulacao{Intervalo: tick, Semente: replay.Semente, Deterministico: true}
+	entrada := fonteRoteiroNova(roteiro)
+
+	// No terminal, o teclado controla a reprodução (pausa, passo, velocidade)
+	var controles chan ControleReplay
+	if !*semTela {
+		controles = make(chan ControleReplay)
+		config.Controles = controles
+	}
+
+	jogo, err := carregarPartidaReplay(replay, &config)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	executarPartida(jogo, config, entrada, *semTela, controles)
+}
+
+// Cria o jogo a partir de um jogo salvo, do primeiro nível de uma campanha
+// ou do mapa, nesta ordem de preferência
+// A campanha lida (inclusive a de um jogo salvo) é guardada em config
+// Uma partida gravada a partir de um jogo salvo guarda uma cópia dele no replay
+func carregarPartida(mapaFile, campanhaFile, salvamento string, config *ConfigSimulacao) (Jogo, error) {
+	if salvamento != "" {
+		dados, err := os.ReadFile(salvamento)
+		if err != nil {
+			return Jogo{}, err
+		}
+		if config.Gravacao != nil {
+			config.Gravacao.Inicio = dados
+		}
+		return carregarSalvamento(salvamento, dados, config)
+	}
 
-	// Desenha o estado inicial do jogo
-	interfaceDesenharJogo(&jogo)
+	jogo := jogoNovo()
+	if campanhaFile != "" {
+		campanha, err := campanhaCarregar(campanhaFile)
+		if err != nil {
+			return Jogo{}, err
+		}
+		config.Campanha = campanha
+		return jogoCarregarNivel(&jogo, campanha, 1)
+	}
 
-	go piscarcor(&jogo)
+	if err := jogoCarregarMapa(mapaFile, &jogo); err != nil {
+		return Jogo{}, err
+	}
+	return jogo, nil
+}
 
-	// Loop principal de entrada
-	for {
-		evento := interfaceLerEventoTeclado()
-		if continuar := personagemExecutarAcao(evento, &jogo, chanVida); !continuar {
-			break
+// Cria o jogo de onde a partida gravada no replay começou
+// O jogo salvo guardado no replay prevalece sobre o arquivo, que pode ter
+// mudado depois da gravação (replays antigos só têm o nome do arquivo)
+func carregarPartidaReplay(replay *Replay, config *ConfigSimulacao) (Jogo, error) {
+	if len(replay.Inicio) > 0 {
+		return carregarSalvamento(replay.Salvamento, replay.Inicio, config)
+	}
+	return carregarPartida(replay.Mapa, replay.Campanha, replay.Salvamento, config)
+}
+
+// Continua o jogo salvo com o conteúdo informado
+// A campanha de um jogo salvo em campanha é guardada em config
+func carregarSalvamento(nome string, dados []byte, config *ConfigSimulacao) (Jogo, error) {
+	jogo, err := jogoInterpretarSalvamento(nome, dados)
+	if err != nil {
+		return Jogo{}, err
+	}
+	if jogo.Campanha.Arquivo != "" {
+		config.Campanha, err = campanhaCarregar(jogo.Campanha.Arquivo)
+	}
+	return jogo, err
+}
+
+// Executa a simulação do jogo até o fim, desenhando cada quadro no
+// terminal ou, com semTela, em memória (o último quadro é impresso)
+func executarPartida(jogo Jogo, config ConfigSimulacao, entrada FonteEntrada, semTela bool, controles chan<- ControleReplay) {
+	// Inicializa a interface (termbox) ou a tela em memória
+	if semTela {
+		tela = renderizadorMemoriaNovo(LarguraTelaMemoria, AlturaTelaMemoria)
+	} else {
+		interfaceIniciar()
+		defer interfaceFinalizar()
+		if entrada == nil {
+			entrada = fonteTermboxNova()
+		}
+		if controles != nil {
+			go replayLerControles(controles)
 		}
+	}
+
+	// A simulação é a única dona do estado do jogo; os demais componentes
+	// conversam com ela por canais
+	sim := simulacaoNova(config, entrada)
 
-		// Atualiza bombas e explosões
-		jogoAtualizarBombas(&jogo)
-		jogoAtualizarExplosoes(&jogo)
+	// A simulação roda em sua própria goroutine até o jogador sair
+	go simulacaoExecutar(sim, jogo)
 
-		// Verifica condições de fim de jogo (atualiza StatusMsg se necessário)
-		jogoVerificarDerrota(&jogo)
-		jogoVerificarVitoria(&jogo)
+	// Desenha cada quadro recebido da simulação até que ela termine
+	interfaceExecutarRenderizador(sim.quadros)
 
-		interfaceDesenharJogo(&jogo)
+	// Sem terminal, o último quadro desenhado é impresso na saída padrão
+	if memoria, ok := tela.(*RenderizadorMemoria); ok {
+		fmt.Print(memoria.Texto())
 	}
 }
@@ -0,0 +1,379 @@
+// mapa.go - Formato dos arquivos de mapa: cabeçalho, legenda e grade
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CabecalhoMapa reúne os metadados e as regras opcionais de um mapa
+type CabecalhoMapa struct {
+	Nome             string        // nome do mapa exibido no jogo
+	Autor            string        // autor do mapa
+	VidaMaxima       int           // limite de corações do jogador
+	IntervaloInimigo time.Duration // intervalo entre duas ações de um inimigo (velocidade)
+	RaioBomba        int           // alcance das explosões
+	Vitoria          string        // condição de vitória (VitoriaInimigos ou VitoriaCuras)
+	IntervaloNinhos  time.Duration // tempo entre dois inimigos gerados por um ninho
+	LimiteNinhos     int           // inimigos vivos de um mesmo ninho ao mesmo tempo
+	InimigoNinhos    string        // tipo dos inimigos gerados pelos ninhos
+	FormaBomba       string        // forma inicial das explosões (veja FormasBomba)
+	FogoAmigo        bool          // as explosões também ferem o personagem
+	CapacidadeBombas int           // bombas que o jogador carrega ao mesmo tempo
+	RecargaBomba     time.Duration // tempo para recuperar uma bomba usada
+}
+
+// Condições de vitória aceitas no cabeçalho
+const (
+	VitoriaInimigos = "inimigos" // eliminar todos os inimigos
+	VitoriaCuras    = "curas"    // coletar todas as curas do mapa
+)
+
+// Tipos de elemento que a legenda pode associar a um caractere
+const (
+	TipoVazio      = "vazio"
+	TipoParede     = "parede"
+	TipoVegetacao  = "vegetacao"
+	TipoCura       = "cura"
+	TipoInimigo    = "inimigo"
+	TipoPersonagem = "personagem"
+	TipoNinho      = "ninho"
+	TipoFragil     = "fragil"
+
+	// Tipos de inimigo além do comum (veja arquetiposInimigo)
+	TipoBatedor  = "batedor"
+	TipoTanque   = "tanque"
+	TipoFantasma = "fantasma"
+	TipoCovarde  = "covarde"
+	TipoAtirador = "atirador"
+	TipoChefe    = "chefe"
+)
+
+// Modos de percorrer uma rota de patrulha
+const (
+	RotaCiclo  = "ciclo"  // do último ponto volta ao primeiro
+	RotaVaiVem = "vaivem" // do último ponto volta pelo mesmo caminho
+)
+
+// Rota é o caminho de patrulha de um inimigo definido no mapa
+// O primeiro ponto é a posição inicial do inimigo que a segue
+type Rota struct {
+	Modo   string  // RotaCiclo ou RotaVaiVem
+	Pontos []Ponto // pontos de passagem, na ordem em que são visitados
+}
+
+// RotaMapa é uma rota lida do arquivo, com a linha onde foi definida
+type RotaMapa struct {
+	Rota
+	Linha int // número da linha do arquivo, para as mensagens de erro
+}
+
+// ArquivoMapa é o conteúdo de um arquivo de mapa já separado em partes
+type ArquivoMapa struct {
+	Cabecalho     CabecalhoMapa
+	Legenda       map[rune]string // caractere -> tipo de elemento
+	Rotas         []RotaMapa      // rotas de patrulha dos inimigos
+	Linhas        []string        // linhas da grade, como estão no arquivo
+	PrimeiraLinha int             // número da linha do arquivo onde a grade começa
+}
+
+// Retorna as regras usadas quando o mapa não tem cabeçalho
+func mapaCabecalhoPadrao() CabecalhoMapa {
+	return CabecalhoMapa{
+		VidaMaxima:       5,
+		IntervaloInimigo: IntervaloInimigo,
+		RaioBomba:        5,
+		Vitoria:          VitoriaInimigos,
+		IntervaloNinhos:  IntervaloNinhoPadrao,
+		LimiteNinhos:     LimiteNinhoPadrao,
+		InimigoNinhos:    TipoInimigo,
+		FormaBomba:       FormaLosango,
+		FogoAmigo:        true,
+		CapacidadeBombas: CapacidadeBombasPadrao,
+		RecargaBomba:     RecargaBombaPadrao,
+	}
+}
+
+// Retorna a legenda com os símbolos do jogo, usada por todo mapa
+// A legenda de um arquivo acrescenta ou substitui entradas desta
+func mapaLegendaPadrao() map[rune]string {
+	legenda := map[rune]string{
+		Vazio.simbolo:        TipoVazio,
+		Parede.simbolo:       TipoParede,
+		Vegetacao.simbolo:    TipoVegetacao,
+		Cura.simbolo:         TipoCura,
+		Personagem.simbolo:   TipoPersonagem,
+		NinhoElem.simbolo:    TipoNinho,
+		ParedeFragil.simbolo: TipoFragil,
+	}
+	for _, a := range arquetiposInimigo {
+		legenda[a.Sprite.simbolo] = a.Tipo
+	}
+	return legenda
+}
+
+// ============================================================================
+// MÓDULO DE LEITURA DO ARQUIVO DE MAPA
+// ============================================================================
+
+// Lê um arquivo de mapa
+// Um mapa simples contém apenas a grade de símbolos (como mapa.txt). O
+// formato estendido divide o arquivo em seções opcionais antes da grade:
+//
+//	[cabecalho]
+//	nome: Floresta
+//	autor: Maria
+//	vida_maxima: 4
+//	velocidade_inimigos: 300ms
+//	raio_bomba: 3
+//	forma_bomba: cruz
+//	fogo_amigo: nao
+//	bombas: 2
+//	recarga_bomba: 5s
+//	vitoria: curas
+//	intervalo_ninhos: 8s
+//	limite_ninhos: 2
+//	inimigo_ninhos: batedor
+//	[legenda]
+//	# = parede
+//	E = inimigo
+//	[rotas]
+//	vaivem: 5,1 2,1
+//	[mapa]
+//	#######
+//	#@ . E#
+//	#######
+//
+// Cada linha da seção de rotas dá o modo (ciclo ou vaivem) e os pontos x,y da
+// patrulha de um inimigo, contados a partir de 0 no canto superior esquerdo
+// da grade; o primeiro ponto é a posição do inimigo. Linhas começando com #
+// são comentários nas seções de cabeçalho, legenda e rotas
+func mapaLer(nome string) (*ArquivoMapa, error) {
+	arq, err := os.Open(nome)
+	if err != nil {
+		return nil, err
+	}
+	defer arq.Close()
+
+	return mapaInterpretar(nome, arq)
+}
+
+// Interpreta o conteúdo de um mapa no formato descrito em mapaLer
+// O nome identifica a origem do mapa nas mensagens de erro
+func mapaInterpretar(nome string, r io.Reader) (*ArquivoMapa, error) {
+	mapa := &ArquivoMapa{
+		Cabecalho:     mapaCabecalhoPadrao(),
+		Legenda:       mapaLegendaPadrao(),
+		PrimeiraLinha: 1,
+	}
+
+	scanner := bufio.NewScanner(r)
+	secao := ""
+	numLinha := 0
+	for scanner.Scan() {
+		numLinha++
+		linha := scanner.Text()
+
+		// Sem seção na primeira linha, o arquivo inteiro é a grade
+		if numLinha == 1 && !mapaEhSecao(linha) {
+			secao = "mapa"
+		}
+		if secao != "mapa" && mapaEhSecao(linha) {
+			secao = strings.Trim(strings.TrimSpace(linha), "[]")
+			mapa.PrimeiraLinha = numLinha + 1
+			continue
+		}
+
+		switch secao {
+		case "mapa":
+			mapa.Linhas = append(mapa.Linhas, linha)
+		case "cabecalho":
+			if err := mapaLerCabecalho(&mapa.Cabecalho, linha); err != nil {
+				return nil, fmt.Errorf("%s:%d: %v", nome, numLinha, err)
+			}
+		case "legenda":
+			if err := mapaLerLegenda(mapa.Legenda, linha); err != nil {
+				return nil, fmt.Errorf("%s:%d: %v", nome, numLinha, err)
+			}
+		case "rotas":
+			if err := mapaLerRota(&mapa.Rotas, linha, numLinha); err != nil {
+				return nil, fmt.Errorf("%s:%d: %v", nome, numLinha, err)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if secao != "mapa" {
+		return nil, fmt.Errorf("%s: seção [mapa] não encontrada", nome)
+	}
+	return mapa, nil
+}
+
+// Indica se a linha abre uma seção do formato estendido
+func mapaEhSecao(linha string) bool {
+	switch strings.TrimSpace(linha) {
+	case "[cabecalho]", "[legenda]", "[rotas]", "[mapa]":
+		return true
+	}
+	return false
+}
+
+// Interpreta uma linha "chave: valor" da seção de cabeçalho
+func mapaLerCabecalho(cab *CabecalhoMapa, linha string) error {
+	linha = strings.TrimSpace(linha)
+	if linha == "" || strings.HasPrefix(linha, "#") {
+		return nil // Ignora linhas vazias e comentários
+	}
+
+	chave, valor, ok := strings.Cut(linha, ":")
+	if !ok {
+		return fmt.Errorf("esperado \"chave: valor\"")
+	}
+	chave, valor = strings.TrimSpace(chave), strings.TrimSpace(valor)
+
+	switch chave {
+	case "nome":
+		cab.Nome = valor
+	case "autor":
+		cab.Autor = valor
+	case "vida_maxima", "raio_bomba", "limite_ninhos", "bombas":
+		n, err := strconv.Atoi(valor)
+		if err != nil || n <= 0 {
+			return fmt.Errorf("%s deve ser um número positivo: %q", chave, valor)
+		}
+		switch chave {
+		case "vida_maxima":
+			cab.VidaMaxima = n
+		case "raio_bomba":
+			cab.RaioBomba = n
+		case "bombas":
+			cab.CapacidadeBombas = n
+		default:
+			cab.LimiteNinhos = n
+		}
+	case "velocidade_inimigos", "intervalo_ninhos", "recarga_bomba":
+		d, err := time.ParseDuration(valor)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("%s deve ser uma duração positiva (ex.: 500ms): %q", chave, valor)
+		}
+		switch chave {
+		case "velocidade_inimigos":
+			cab.IntervaloInimigo = d
+		case "intervalo_ninhos":
+			cab.IntervaloNinhos = d
+		default:
+			cab.RecargaBomba = d
+		}
+	case "inimigo_ninhos":
+		if !arquetipoEhInimigo(valor) || valor == TipoChefe {
+			return fmt.Errorf("inimigo_ninhos deve ser um tipo de inimigo que não seja chefe: %q", valor)
+		}
+		cab.InimigoNinhos = valor
+	case "forma_bomba":
+		if !explosaoFormaValida(valor) {
+			return fmt.Errorf("forma_bomba deve ser uma de %s: %q", strings.Join(FormasBomba, ", "), valor)
+		}
+		cab.FormaBomba = valor
+	case "fogo_amigo":
+		if valor != "sim" && valor != "nao" {
+			return fmt.Errorf("fogo_amigo deve ser \"sim\" ou \"nao\": %q", valor)
+		}
+		cab.FogoAmigo = valor == "sim"
+	case "vitoria":
+		if valor != VitoriaInimigos && valor != VitoriaCuras {
+			return fmt.Errorf("vitoria deve ser %q ou %q: %q", VitoriaInimigos, VitoriaCuras, valor)
+		}
+		cab.Vitoria = valor
+	default:
+		return fmt.Errorf("chave de cabeçalho desconhecida %q", chave)
+	}
+	return nil
+}
+
+// Interpreta uma linha "<caractere> = <tipo>" da seção de legenda
+// O caractere é sempre o primeiro da linha, então espaço e # também podem
+// ser associados a um tipo
+func mapaLerLegenda(legenda map[rune]string, linha string) error {
+	simbolos := []rune(linha)
+	if len(simbolos) == 0 {
+		return nil
+	}
+
+	resto := strings.TrimSpace(string(simbolos[1:]))
+	if !strings.HasPrefix(resto, "=") {
+		if simbolos[0] == '#' || strings.TrimSpace(linha) == "" {
+			return nil // Comentário ou linha em branco
+		}
+		return fmt.Errorf("esperado \"<caractere> = <tipo>\"")
+	}
+
+	tipo := strings.TrimSpace(strings.TrimPrefix(resto, "="))
+	if _, ok := mapaElementoDoTipo(tipo); !ok {
+		return fmt.Errorf("tipo de elemento desconhecido %q", tipo)
+	}
+	legenda[simbolos[0]] = tipo
+	return nil
+}
+
+// Interpreta uma linha "<modo>: x,y x,y ..." da seção de rotas
+func mapaLerRota(rotas *[]RotaMapa, linha string, numLinha int) error {
+	linha = strings.TrimSpace(linha)
+	if linha == "" || strings.HasPrefix(linha, "#") {
+		return nil // Ignora linhas vazias e comentários
+	}
+
+	modo, pontos, ok := strings.Cut(linha, ":")
+	if !ok {
+		return fmt.Errorf("esperado \"<modo>: x,y x,y ...\"")
+	}
+	rota := RotaMapa{Rota: Rota{Modo: strings.TrimSpace(modo)}, Linha: numLinha}
+	if rota.Modo != RotaCiclo && rota.Modo != RotaVaiVem {
+		return fmt.Errorf("modo de rota deve ser %q ou %q: %q", RotaCiclo, RotaVaiVem, rota.Modo)
+	}
+
+	for _, campo := range strings.Fields(pontos) {
+		xs, ys, ok := strings.Cut(campo, ",")
+		x, errX := strconv.Atoi(xs)
+		y, errY := strconv.Atoi(ys)
+		if !ok || errX != nil || errY != nil || x < 0 || y < 0 {
+			return fmt.Errorf("ponto de rota inválido %q (esperado x,y)", campo)
+		}
+		rota.Pontos = append(rota.Pontos, Ponto{x, y})
+	}
+	if len(rota.Pontos) < 2 {
+		return fmt.Errorf("a rota precisa de ao menos dois pontos")
+	}
+
+	*rotas = append(*rotas, rota)
+	return nil
+}
+
+// Retorna o elemento que representa o tipo informado
+func mapaElementoDoTipo(tipo string) (Elemento, bool) {
+	switch tipo {
+	case TipoVazio:
+		return Vazio, true
+	case TipoParede:
+		return Parede, true
+	case TipoVegetacao:
+		return Vegetacao, true
+	case TipoCura:
+		return Cura, true
+	case TipoPersonagem:
+		return Personagem, true
+	case TipoNinho:
+		return NinhoElem, true
+	case TipoFragil:
+		return ParedeFragil, true
+	}
+	if a, ok := arquetipoBuscar(tipo); ok {
+		return a.Sprite, true
+	}
+	return Elemento{}, false
+}
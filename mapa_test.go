@@ -0,0 +1,90 @@
+// mapa_test.go - Testes da leitura dos arquivos de mapa
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Mapa no formato estendido, com todas as seções e uma legenda própria
+const mapaTesteEstendido = `[cabecalho]
+# comentário
+nome: Floresta
+autor: Maria
+vida_maxima: 4
+velocidade_inimigos: 300ms
+raio_bomba: 3
+forma_bomba: cruz
+fogo_amigo: nao
+vitoria: curas
+[legenda]
+# = parede
+E = inimigo
+. = vegetacao
+[rotas]
+vaivem: 5,1 2,1
+[mapa]
+#######
+#@ . E#
+#######`
+
+// As seções do formato estendido preenchem o cabeçalho, a legenda e as
+// rotas, e a grade é guardada como está no arquivo
+func TestMapaInterpretar(t *testing.T) {
+	arq, err := mapaInterpretar("teste.txt", strings.NewReader(mapaTesteEstendido))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cab := arq.Cabecalho
+	if cab.Nome != "Floresta" || cab.Autor != "Maria" || cab.VidaMaxima != 4 || cab.IntervaloInimigo != 300*time.Millisecond ||
+		cab.RaioBomba != 3 || cab.FormaBomba != FormaCruz || cab.FogoAmigo || cab.Vitoria != VitoriaCuras {
+		t.Errorf("cabeçalho %+v", cab)
+	}
+	if cab.CapacidadeBombas != CapacidadeBombasPadrao {
+		t.Errorf("chave ausente mudou do padrão: bombas = %d", cab.CapacidadeBombas)
+	}
+
+	for ch, tipo := range map[rune]string{'#': TipoParede, 'E': TipoInimigo, '.': TipoVegetacao, '@': ""} {
+		if arq.Legenda[ch] != tipo {
+			t.Errorf("legenda[%q] = %q, esperado %q", ch, arq.Legenda[ch], tipo)
+		}
+	}
+	if arq.Legenda[Parede.simbolo] != TipoParede || arq.Legenda[Personagem.simbolo] != TipoPersonagem {
+		t.Error("a legenda do arquivo apagou os símbolos padrão")
+	}
+
+	if len(arq.Rotas) != 1 {
+		t.Fatalf("rotas %v, esperada uma", arq.Rotas)
+	}
+	rota := arq.Rotas[0]
+	if rota.Modo != RotaVaiVem || !slices.Equal(rota.Pontos, []Ponto{{5, 1}, {2, 1}}) || rota.Linha != 16 {
+		t.Errorf("rota %+v, esperada vaivem de (5, 1) a (2, 1) na linha 16", rota)
+	}
+
+	if !slices.Equal(arq.Linhas, []string{"#######", "#@ . E#", "#######"}) || arq.PrimeiraLinha != 18 {
+		t.Errorf("grade %q a partir da linha %d, esperada a partir da linha 18", arq.Linhas, arq.PrimeiraLinha)
+	}
+}
+
+// Um arquivo sem seções é só a grade; erros de uma seção apontam a linha
+func TestMapaInterpretarSimplesEErros(t *testing.T) {
+	arq, err := mapaInterpretar("teste.txt", strings.NewReader("▤▤▤\n▤☺▤\n▤▤▤"))
+	if err != nil || len(arq.Linhas) != 3 || arq.PrimeiraLinha != 1 || arq.Cabecalho != mapaCabecalhoPadrao() {
+		t.Errorf("mapa simples: %+v, erro %v", arq, err)
+	}
+
+	casos := map[string]string{
+		"[cabecalho]\nnome: X\nvelocidade: 1s\n[mapa]\n▤": "teste.txt:3: chave de cabeçalho desconhecida",
+		"[legenda]\nE = dragao\n[mapa]\n▤":                "teste.txt:2: tipo de elemento desconhecido",
+		"[rotas]\nciclo: 1,1\n[mapa]\n▤":                  "teste.txt:2: a rota precisa de ao menos dois pontos",
+		"[cabecalho]\nnome: X":                            "teste.txt: seção [mapa] não encontrada",
+	}
+	for texto, esperado := range casos {
+		if _, err := mapaInterpretar("teste.txt", strings.NewReader(texto)); err == nil || !strings.HasPrefix(err.Error(), esperado) {
+			t.Errorf("%q: erro %v, esperado %q", texto, err, esperado)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// ninho.go - Ninhos: células do mapa que geram novos inimigos
+package main
+
+import "time"
+
+// Regras padrão dos ninhos, que o cabeçalho do mapa pode alterar
+const (
+	IntervaloNinhoPadrao = 10 * time.Second // tempo entre dois inimigos gerados por um ninho
+	LimiteNinhoPadrao    = 3                // inimigos vivos de um mesmo ninho ao mesmo tempo
+)
+
+// Pontos por ninho destruído
+const PontosNinho = 200
+
+// Ninho é uma célula do mapa que gera inimigos até ser destruída por uma bomba
+type Ninho struct {
+	ID      int       // identificador único (mesma sequência das entidades)
+	X, Y    int       // posição no mapa
+	Proximo time.Time // instante da próxima geração pelo tempo (zero: ainda não agiu)
+}
+
+// Elemento do ninho no mapa: bloqueia a passagem como uma parede
+var NinhoElem = Elemento{'◎', CorVermelho, CorPadrao, true}
+
+// ============================================================================
+// MÓDULO DE CONSULTA DOS NINHOS
+// ============================================================================
+
+// Procura o ninho com o ID informado
+// Retorna seu índice em jogo.Ninhos ou -1 se ele já foi destruído
+func jogoBuscarNinho(jogo *Jogo, id int) int {
+	for i, n := range jogo.Ninhos {
+		if n.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// Conta os inimigos vivos gerados pelo ninho (ou pelo chefe) de ID informado
+func ninhoContarInimigos(jogo *Jogo, id int) int {
+	n := 0
+	for _, ent := range jogo.Entidades[1:] {
+		if ent.Ninho == id {
+			n++
+		}
+	}
+	return n
+}
+
+// Procura a célula vizinha ao ninho onde um novo inimigo pode surgir
+// As direções são testadas em ordem fixa; retorna false se todas estão
+// ocupadas por paredes, inimigos ou pelo personagem
+func ninhoSaida(jogo *Jogo, ninho Ninho) (Ponto, bool) {
+	for _, d := range direcoesCaminho {
+		x, y := ninho.X+d.X, ninho.Y+d.Y
+		if jogoPodeMoverParaPersonagem(jogo, x, y) && (x != jogo.Entidades[0].X || y != jogo.Entidades[0].Y) {
+			return Ponto{x, y}, true
+		}
+	}
+	return Ponto{}, false
+}
+
+// ============================================================================
+// MÓDULO DE DESTRUIÇÃO
+// ============================================================================
+
+// Destrói o ninho atingido por uma explosão na posição (x, y), se houver
+// Os inimigos que ele gerou continuam no mapa
+func jogoVerificarNinhoNaExplosao(jogo *Jogo, x, y int) {
+	for i, n := range jogo.Ninhos {
+		if n.X == x && n.Y == y {
+			jogoTrocarCelula(jogo, x, y, Vazio)
+			jogo.Ninhos = append(jogo.Ninhos[:i], jogo.Ninhos[i+1:]...)
+			jogo.Pontos += PontosNinho
+			jogo.StatusMsg = "Ninho destruído pela explosão!"
+			return
+		}
+	}
+}
+
+// ============================================================================
+// MÓDULO DE PROCESSAMENTO DE AÇÕES
+// ============================================================================
+
+// Goroutine de um ninho: gera um inimigo a cada intervalo do mapa ou ao
+// receber um alarme pelo canal sinais, até o limite de inimigos vivos
+func ninhoExecutar(id int, vez <-chan Jogo, sinais <-chan struct{}, sair <-chan struct{}, acoes chan<- Comando) {
+	simulacaoAgirNaVez(vez, sair, acoes, func(quadro *Jogo) Comando {
+		idx := jogoBuscarNinho(quadro, id)
+		if idx < 0 {
+			return ComandoGerarInimigo{Ninho: id} // Ninho não está no quadro
+		}
+		proximo := quadro.Ninhos[idx].Proximo
+		if proximo.IsZero() {
+			proximo = quadro.Tempo.Add(quadro.Cabecalho.IntervaloNinhos)
+		}
+
+		// Sinais enviados antes desta vez já estão no canal
+		sinal := false
+		select {
+		case <-sinais:
+			sinal = true
+		default:
+		}
+
+		gerar := false
+		if sinal || !quadro.Tempo.Before(proximo) {
+			gerar = ninhoContarInimigos(quadro, id) < quadro.Cabecalho.LimiteNinhos
+			proximo = quadro.Tempo.Add(quadro.Cabecalho.IntervaloNinhos)
+		}
+		return ComandoGerarInimigo{Ninho: id, Gerar: gerar, Proximo: proximo}
+	})
+}
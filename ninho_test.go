@@ -0,0 +1,53 @@
+// ninho_test.go - Testes da geração de inimigos pelos ninhos
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// Os ninhos geram um inimigo a cada intervalo do mapa ou ao receber um
+// alarme, mas nunca passam do limite de inimigos vivos
+func TestNinhoGeraAteOLimite(t *testing.T) {
+	jogo := testeCarregarMapa(t, "testdata/ninho.txt")
+	relogio := relogioSimuladoNovo()
+	jogo.Tempo = relogio.Agora()
+	id := jogo.Ninhos[0].ID
+
+	vez, sinais, sair := make(chan Jogo), make(chan struct{}, 1), make(chan struct{})
+	acoes := make(chan Comando)
+	defer close(sair)
+	go ninhoExecutar(id, vez, sinais, sair, acoes)
+
+	passos := []struct {
+		nome    string
+		avanco  time.Duration
+		alarme  bool
+		morte   bool // um inimigo do ninho morre antes da vez
+		gerados int
+	}{
+		{"primeira vez", 0, false, false, 0},
+		{"antes do intervalo", 500 * time.Millisecond, false, false, 0},
+		{"fim do intervalo", 500 * time.Millisecond, false, false, 1},
+		{"alarme antes do intervalo", 100 * time.Millisecond, true, false, 2},
+		{"intervalo com o limite atingido", time.Second, false, false, 2},
+		{"alarme com o limite atingido", 100 * time.Millisecond, true, false, 2},
+		{"intervalo depois de um inimigo morrer", time.Second, false, true, 2},
+	}
+	for _, passo := range passos {
+		relogio.Avancar(passo.avanco)
+		jogo.Tempo = relogio.Agora()
+		if passo.morte {
+			jogoRemoverEntidade(&jogo, len(jogo.Entidades)-1)
+		}
+		if passo.alarme {
+			sinais <- struct{}{}
+		}
+
+		vez <- jogoCopiar(&jogo)
+		(<-acoes).executar(&jogo)
+		if n := ninhoContarInimigos(&jogo, id); n != passo.gerados {
+			t.Errorf("%s: %d inimigos do ninho, esperados %d", passo.nome, n, passo.gerados)
+		}
+	}
+}
@@ -6,7 +6,7 @@ package main
 // ============================================================================
 
 // Move o personagem baseado na tecla WASD pressionada
-func personagemMover(tecla rune, jogo *Jogo, chanVida chan int) {
+func personagemMover(tecla rune, jogo *Jogo) {
 	dx, dy := 0, 0
 
 	// Define direção baseada na tecla
@@ -31,7 +31,7 @@ func personagemMover(tecla rune, jogo *Jogo, chanVida chan int) {
 	if jogoPodeMoverParaPersonagem(jogo, nx, ny) {
 		// Verifica se há cura na posição de destino
 		if jogo.Mapa[ny][nx].simbolo == Cura.simbolo {
-			coletarCura(jogo, nx, ny, chanVida)
+			coletarCura(jogo, nx, ny)
 		}
 		jogoMoverElemento(jogo, jogo.Entidades[0].X, jogo.Entidades[0].Y, dx, dy, &jogo.Entidades[0])
 	}
@@ -42,14 +42,15 @@ func personagemMover(tecla rune, jogo *Jogo, chanVida chan int) {
 // ============================================================================
 
 // Coleta uma cura na posição especificada
-func coletarCura(jogo *Jogo, x, y int, chanVida chan int) {
+func coletarCura(jogo *Jogo, x, y int) {
 	// Remove cura do mapa
 	jogo.Mapa[y][x] = Vazio
 
 	// Marca cura como usada
 	jogo.CuraUsada = true
+	jogo.Pontos += PontosCura
 
-	chanVida <- 1 // Envia sinal para aumentar vida
+	jogoAlterarVida(jogo, 1) // Aumenta a vida do jogador
 }
 
 // ============================================================================
@@ -57,7 +58,12 @@ func coletarCura(jogo *Jogo, x, y int, chanVida chan int) {
 // ============================================================================
 
 // Processa eventos de teclado e executa ações do personagem
-func personagemExecutarAcao(ev EventoTeclado, jogo *Jogo, chanVida chan int) bool {
+func personagemExecutarAcao(ev EventoTeclado, jogo *Jogo) bool {
+	// Durante a apresentação do nível o personagem não age
+	if jogoEmIntroducao(jogo) && ev.Tipo != "sair" {
+		return true
+	}
+
 	switch ev.Tipo {
 	case "sair":
 		// Termina o jogo
@@ -65,11 +71,15 @@ func personagemExecutarAcao(ev EventoTeclado, jogo *Jogo, chanVida chan int) boo
 
 	case "mover":
 		// Executa movimento do personagem
-		personagemMover(ev.Tecla, jogo, chanVida)
+		personagemMover(ev.Tecla, jogo)
 
 	case "bomba":
 		// Coloca uma bomba na posição atual
 		jogoColocarBomba(jogo)
+
+	case "forma":
+		// Troca a forma das próximas bombas
+		jogoTrocarFormaBomba(jogo)
 	}
 
 	// Continua o jogo
@@ -0,0 +1,115 @@
+// projetil.go - Projéteis disparados pelos inimigos atiradores
+package main
+
+import "time"
+
+// Tempo mínimo entre dois disparos do mesmo inimigo
+const RecargaDisparo = 2 * time.Second
+
+// Projetil é um tiro em voo, que anda uma célula por tick em linha reta
+type Projetil struct {
+	ID     int // identificador único (mesma sequência das entidades)
+	X, Y   int // posição atual
+	DX, DY int // direção do voo
+	Dano   int // corações tirados ao atingir o personagem
+}
+
+// Sprites dos projéteis, conforme a direção do voo
+var (
+	ProjetilHorizontal = Elemento{'─', CorAmarelo, CorPadrao, false}
+	ProjetilVertical   = Elemento{'│', CorAmarelo, CorPadrao, false}
+)
+
+// ============================================================================
+// MÓDULO DE DISPARO
+// ============================================================================
+
+// Indica a direção em que o inimigo pode atirar no personagem
+// O inimigo precisa ser de um tipo que atira, estar alinhado com o
+// personagem na mesma linha ou coluna, enxergá-lo e ter a arma recarregada
+func projetilMirar(jogo *Jogo, idx int, visto bool, mente *MenteInimigo) (Ponto, bool) {
+	ent, p := jogo.Entidades[idx], jogo.Entidades[0]
+	if !arquetipoDe(ent).Atira || !visto || jogo.Tempo.Before(mente.ProximoDisparo) {
+		return Ponto{}, false
+	}
+	if ent.X != p.X && ent.Y != p.Y {
+		return Ponto{}, false // Desalinhado
+	}
+
+	return Ponto{projetilSinal(p.X - ent.X), projetilSinal(p.Y - ent.Y)}, true
+}
+
+// Retorna -1, 0 ou 1 conforme o sinal de n
+func projetilSinal(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+// ============================================================================
+// MÓDULO DE CONSULTA DOS PROJÉTEIS
+// ============================================================================
+
+// Procura o projétil com o ID informado
+// Retorna seu índice em jogo.Projeteis ou -1 se ele não existe mais
+func jogoBuscarProjetil(jogo *Jogo, id int) int {
+	for i, p := range jogo.Projeteis {
+		if p.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// Retorna o sprite do projétil conforme a direção do voo
+func projetilSprite(p Projetil) Elemento {
+	if p.DX != 0 {
+		return ProjetilHorizontal
+	}
+	return ProjetilVertical
+}
+
+// ============================================================================
+// MÓDULO DE PROCESSAMENTO DE AÇÕES
+// ============================================================================
+
+// Goroutine de um projétil: avança uma célula a cada vez (uma por tick) e
+// envia dano pelo canal chanVida ao atingir o personagem
+func projetilExecutar(id int, vez <-chan Jogo, sair <-chan struct{}, acoes chan<- Comando, chanVida chan<- int) {
+	simulacaoAgirNaVez(vez, sair, acoes, func(quadro *Jogo) Comando {
+		cmd, dano := projetilAgir(quadro, id)
+		if dano > 0 {
+			chanVida <- -dano // Envia sinal para diminuir vida
+		}
+		return cmd
+	})
+}
+
+// Decide o próximo passo do projétil
+// O projétil para ao atingir o personagem (na célula atual ou na próxima) ou
+// ao encontrar uma parede ou o fim do mapa. Retorna o comando e o dano
+// causado ao personagem
+func projetilAgir(jogo *Jogo, id int) (ComandoMoverProjetil, int) {
+	idx := jogoBuscarProjetil(jogo, id)
+	if idx < 0 {
+		return ComandoMoverProjetil{ID: id, Remover: true}, 0
+	}
+
+	p, alvo := jogo.Projeteis[idx], jogo.Entidades[0]
+	nx, ny := p.X+p.DX, p.Y+p.DY
+	switch {
+	case (p.X == alvo.X && p.Y == alvo.Y) || (nx == alvo.X && ny == alvo.Y):
+		dano := 0
+		if jogo.Vida > 0 {
+			dano = p.Dano
+		}
+		return ComandoMoverProjetil{ID: id, Remover: true}, dano
+	case !jogoPodeMoverPara(jogo, nx, ny):
+		return ComandoMoverProjetil{ID: id, Remover: true}, 0 // Bateu na parede
+	}
+	return ComandoMoverProjetil{ID: id}, 0
+}
@@ -0,0 +1,61 @@
+// projetil_test.go - Testes dos projéteis dos atiradores
+package main
+
+import "testing"
+
+// Lança o projétil no mapa e dá a vez à sua goroutine até ele parar,
+// aplicando o dano recebido pelo canal chanVida como a simulação faz.
+// Retorna as posições por onde ele passou
+func testeVooProjetil(t *testing.T, jogo *Jogo, p Projetil) []Ponto {
+	t.Helper()
+	jogo.Projeteis = append(jogo.Projeteis, p)
+	vez, sair := make(chan Jogo), make(chan struct{})
+	acoes, chanVida := make(chan Comando), make(chan int)
+	defer close(sair)
+	go projetilExecutar(p.ID, vez, sair, acoes, chanVida)
+
+	var posicoes []Ponto
+	for i := 0; jogoBuscarProjetil(jogo, p.ID) >= 0; i++ {
+		if i > len(jogo.Mapa[0]) {
+			t.Fatalf("projétil não parou: %v", posicoes)
+		}
+		atual := jogo.Projeteis[jogoBuscarProjetil(jogo, p.ID)]
+		posicoes = append(posicoes, Ponto{atual.X, atual.Y})
+
+		vez <- jogoCopiar(jogo)
+		select {
+		case v := <-chanVida:
+			jogoAlterarVida(jogo, v)
+			(<-acoes).executar(jogo)
+		case cmd := <-acoes:
+			cmd.executar(jogo)
+		}
+	}
+	return posicoes
+}
+
+// O projétil anda uma célula por vez e para na parede sem ferir ninguém
+func TestProjetilParaNaParede(t *testing.T) {
+	jogo := testeCarregarMapa(t, "testdata/cadeia.txt")
+	vida := jogo.Vida
+	posicoes := testeVooProjetil(t, &jogo, Projetil{ID: 100, X: 6, Y: 2, DX: 1, Dano: 1})
+	if len(posicoes) != 4 || posicoes[3] != (Ponto{9, 2}) {
+		t.Errorf("voo %v, esperado de (6, 2) até (9, 2), junto à parede", posicoes)
+	}
+	if jogo.Vida != vida {
+		t.Errorf("projétil na parede tirou vida: %d", jogo.Vida)
+	}
+}
+
+// O projétil que alcança o personagem para nele e tira o seu dano
+func TestProjetilFerePersonagem(t *testing.T) {
+	jogo := testeCarregarMapa(t, "testdata/cadeia.txt")
+	vida := jogo.Vida
+	posicoes := testeVooProjetil(t, &jogo, Projetil{ID: 100, X: 6, Y: 1, DX: -1, Dano: 2})
+	if len(posicoes) != 5 || posicoes[4] != (Ponto{2, 1}) {
+		t.Errorf("voo %v, esperado de (6, 1) até (2, 1), ao lado do personagem", posicoes)
+	}
+	if jogo.Vida != vida-2 {
+		t.Errorf("vida %d depois do tiro, esperada %d", jogo.Vida, vida-2)
+	}
+}
@@ -0,0 +1,36 @@
+// relogio.go - Relógios que fornecem o tempo da simulação
+package main
+
+import "time"
+
+// Relogio fornece o instante atual do jogo para a simulação
+// Bombas, explosões e o intervalo entre danos usam este tempo em vez de
+// time.Now(), o que permite rodar o jogo com um relógio simulado
+type Relogio interface {
+	Agora() time.Time
+	Avancar(d time.Duration) // chamado a cada tick com o intervalo do tick
+}
+
+// RelogioSistema usa o relógio real do sistema
+type RelogioSistema struct{}
+
+func (RelogioSistema) Agora() time.Time { return time.Now() }
+
+// O tempo real avança sozinho
+func (RelogioSistema) Avancar(time.Duration) {}
+
+// RelogioSimulado só avança quando a simulação executa um tick
+// Com ele, o tempo do jogo depende apenas da quantidade de ticks, e não de
+// quando cada tick foi executado
+type RelogioSimulado struct {
+	agora time.Time
+}
+
+// Cria um relógio simulado parado no instante inicial fixo
+func relogioSimuladoNovo() *RelogioSimulado {
+	return &RelogioSimulado{agora: time.Unix(0, 0).UTC()}
+}
+
+func (r *RelogioSimulado) Agora() time.Time { return r.agora }
+
+func (r *RelogioSimulado) Avancar(d time.Duration) { r.agora = r.agora.Add(d) }
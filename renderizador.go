@@ -0,0 +1,145 @@
+// renderizador.go - Telas onde o jogo pode ser desenhado (terminal ou memória)
+package main
+
+import (
+	"strings"
+
+	"github.com/nsf/termbox-go"
+)
+
+// Renderizador abstrai a tela usada pelas funções de desenho de interface.go
+// Permite desenhar o jogo no terminal ou em uma grade em memória, sem TTY
+type Renderizador interface {
+	DesenharCelula(x, y int, ch rune, cor, corFundo Cor) // desenha um caractere
+	DesenharTexto(x, y int, texto string, cor, corFundo Cor)
+	Tamanho() (largura, altura int) // dimensões da tela em células
+	Limpar()                        // apaga todo o conteúdo ainda não exibido
+	Atualizar()                     // exibe tudo o que foi desenhado
+}
+
+// Tela usada pelas funções de desenho (terminal por padrão)
+var tela Renderizador = RenderizadorTermbox{}
+
+// ============================================================================
+// MÓDULO DE RENDERIZAÇÃO NO TERMINAL (TERMBOX)
+// ============================================================================
+
+// RenderizadorTermbox desenha diretamente no terminal usando o termbox
+type RenderizadorTermbox struct{}
+
+func (RenderizadorTermbox) DesenharCelula(x, y int, ch rune, cor, corFundo Cor) {
+	termbox.SetCell(x, y, ch, cor, corFundo)
+}
+
+func (r RenderizadorTermbox) DesenharTexto(x, y int, texto string, cor, corFundo Cor) {
+	for _, c := range texto {
+		r.DesenharCelula(x, y, c, cor, corFundo)
+		x++
+	}
+}
+
+func (RenderizadorTermbox) Tamanho() (int, int) {
+	return termbox.Size()
+}
+
+func (RenderizadorTermbox) Limpar() {
+	termbox.Clear(CorPadrao, CorPadrao)
+}
+
+func (RenderizadorTermbox) Atualizar() {
+	termbox.Flush()
+}
+
+// ============================================================================
+// MÓDULO DE RENDERIZAÇÃO EM MEMÓRIA
+// ============================================================================
+
+// Celula é o conteúdo de uma posição da tela em memória
+type Celula struct {
+	Simbolo  rune
+	Cor      Cor
+	CorFundo Cor
+}
+
+// RenderizadorMemoria desenha em uma grade em memória, sem terminal
+// O que é desenhado fica em um buffer de trabalho e só passa a valer como
+// quadro exibido após Atualizar, como acontece com o termbox
+type RenderizadorMemoria struct {
+	largura, altura int
+	trabalho        [][]Celula // buffer onde as funções de desenho escrevem
+	exibido         [][]Celula // último quadro exibido (após Atualizar)
+	Quadros         int        // quantidade de quadros exibidos
+}
+
+// Cria uma tela em memória com o tamanho informado, toda em branco
+func renderizadorMemoriaNovo(largura, altura int) *RenderizadorMemoria {
+	return &RenderizadorMemoria{
+		largura:  largura,
+		altura:   altura,
+		trabalho: renderizadorGradeVazia(largura, altura),
+		exibido:  renderizadorGradeVazia(largura, altura),
+	}
+}
+
+// Cria uma grade preenchida com espaços na cor padrão
+func renderizadorGradeVazia(largura, altura int) [][]Celula {
+	grade := make([][]Celula, altura)
+	for y := range grade {
+		grade[y] = make([]Celula, largura)
+		for x := range grade[y] {
+			grade[y][x] = Celula{Simbolo: ' ', Cor: CorPadrao, CorFundo: CorPadrao}
+		}
+	}
+	return grade
+}
+
+func (r *RenderizadorMemoria) DesenharCelula(x, y int, ch rune, cor, corFundo Cor) {
+	// Assim como o termbox, ignora posições fora da tela
+	if x < 0 || x >= r.largura || y < 0 || y >= r.altura {
+		return
+	}
+	r.trabalho[y][x] = Celula{Simbolo: ch, Cor: cor, CorFundo: corFundo}
+}
+
+func (r *RenderizadorMemoria) DesenharTexto(x, y int, texto string, cor, corFundo Cor) {
+	for _, c := range texto {
+		r.DesenharCelula(x, y, c, cor, corFundo)
+		x++
+	}
+}
+
+func (r *RenderizadorMemoria) Tamanho() (int, int) {
+	return r.largura, r.altura
+}
+
+func (r *RenderizadorMemoria) Limpar() {
+	r.trabalho = renderizadorGradeVazia(r.largura, r.altura)
+}
+
+func (r *RenderizadorMemoria) Atualizar() {
+	for y := range r.trabalho {
+		copy(r.exibido[y], r.trabalho[y])
+	}
+	r.Quadros++
+}
+
+// Retorna a célula exibida na posição (x, y)
+func (r *RenderizadorMemoria) Celula(x, y int) Celula {
+	return r.exibido[y][x]
+}
+
+// Retorna o último quadro exibido como texto, uma linha por linha da tela
+// Espaços à direita são removidos para facilitar a comparação com arquivos
+// de referência (golden files)
+func (r *RenderizadorMemoria) Texto() string {
+	var sb strings.Builder
+	for _, linha := range r.exibido {
+		var l strings.Builder
+		for _, c := range linha {
+			l.WriteRune(c.Simbolo)
+		}
+		sb.WriteString(strings.TrimRight(l.String(), " "))
+		sb.WriteByte('\n')
+	}
+	return strings.TrimRight(sb.String(), "\n") + "\n"
+}
@@ -0,0 +1,136 @@
+// replay.go - Gravação e reprodução de partidas
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Replay guarda tudo o que é preciso para repetir uma partida
+// Como a partida gravada roda em modo determinístico, a mesma semente, o
+// mesmo tick e os mesmos eventos reproduzem exatamente o mesmo jogo. Uma
+// partida que começou de um jogo salvo guarda uma cópia dele, já que o
+// arquivo pode ser sobrescrito pela tecla F5 durante a própria partida
+type Replay struct {
+	Versao     string          `json:"versao"`               // versão do jogo que gravou
+	Mapa       string          `json:"mapa"`                 // arquivo de mapa usado
+	Salvamento string          `json:"salvamento,omitempty"` // jogo salvo de onde a partida começou
+	Inicio     json.RawMessage `json:"inicio,omitempty"`     // conteúdo desse jogo salvo ao começar
+	Campanha   string          `json:"campanha,omitempty"`   // manifesto da campanha jogada
+	Semente    uint64          `json:"semente"`              // semente da partida
+	Tick       string          `json:"tick"`                 // intervalo entre os ticks (ex.: "50ms")
+	Eventos    []EventoGravado `json:"eventos"`              // ações do jogador em ordem de tempo
+}
+
+// EventoGravado é uma ação do jogador com o momento em que foi aplicada
+type EventoGravado struct {
+	Tempo string `json:"tempo"` // tempo de jogo desde o início (ex.: "1.25s")
+	Tipo  string `json:"tipo"`  // "sair", "mover", "bomba", "forma" ou "novo"
+	Tecla string `json:"tecla,omitempty"`
+}
+
+// ControleReplay é um comando de reprodução enviado à simulação
+type ControleReplay struct {
+	Tipo       string // "pausar", "passo", "velocidade" ou "sair"
+	Velocidade int    // multiplicador de velocidade (1, 2 ou 4)
+}
+
+// ============================================================================
+// MÓDULO DE GRAVAÇÃO
+// ============================================================================
+
+// Cria uma gravação vazia para a partida informada
+// salvamento é o jogo salvo carregado no início (vazio se começou do mapa)
+func replayNovo(mapa, salvamento string, semente uint64, tick time.Duration) *Replay {
+	return &Replay{
+		Versao:     VersaoJogo,
+		Mapa:       mapa,
+		Salvamento: salvamento,
+		Semente:    semente,
+		Tick:       tick.String(),
+	}
+}
+
+// Registra uma ação do jogador aplicada no tempo de jogo informado
+func replayRegistrar(r *Replay, tempo time.Duration, ev EventoTeclado) {
+	gravado := EventoGravado{Tempo: tempo.String(), Tipo: ev.Tipo}
+	if ev.Tecla != 0 {
+		gravado.Tecla = string(ev.Tecla)
+	}
+	r.Eventos = append(r.Eventos, gravado)
+}
+
+// Grava o replay em um arquivo JSON
+func replaySalvar(r *Replay, nome string) error {
+	dados, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(nome, dados, 0644)
+}
+
+// ============================================================================
+// MÓDULO DE REPRODUÇÃO
+// ============================================================================
+
+// Lê um replay de um arquivo JSON
+func replayCarregar(nome string) (*Replay, error) {
+	dados, err := os.ReadFile(nome)
+	if err != nil {
+		return nil, err
+	}
+
+	var r Replay
+	if err := json.Unmarshal(dados, &r); err != nil {
+		return nil, fmt.Errorf("%s: replay inválido: %v", nome, err)
+	}
+	return &r, nil
+}
+
+// Retorna o intervalo entre ticks usado na gravação
+func replayTick(r *Replay) (time.Duration, error) {
+	tick, err := time.ParseDuration(r.Tick)
+	if err != nil || tick <= 0 {
+		return 0, fmt.Errorf("tick inválido no replay: %q", r.Tick)
+	}
+	return tick, nil
+}
+
+// Converte os eventos gravados em um roteiro de entrada
+func replayRoteiro(r *Replay) ([]EventoTeclado, error) {
+	roteiro := make([]EventoTeclado, 0, len(r.Eventos))
+	for i, gravado := range r.Eventos {
+		tempo, err := time.ParseDuration(gravado.Tempo)
+		if err != nil {
+			return nil, fmt.Errorf("evento %d: tempo inválido: %v", i+1, err)
+		}
+
+		ev := EventoTeclado{Tipo: gravado.Tipo, Tempo: tempo}
+		if teclas := []rune(gravado.Tecla); len(teclas) == 1 {
+			ev.Tecla = teclas[0]
+		}
+		roteiro = append(roteiro, ev)
+	}
+	return roteiro, nil
+}
+
+// Lê o teclado durante a reprodução e converte as teclas em controles
+// Espaço pausa/continua, N avança um tick (pausado), 1/2/4 mudam a velocidade
+// e ESC encerra a reprodução
+func replayLerControles(controles chan<- ControleReplay) {
+	for {
+		ev := interfaceLerEventoTeclado()
+		switch {
+		case ev.Tipo == "sair":
+			controles <- ControleReplay{Tipo: "sair"}
+		case ev.Tecla == ' ':
+			controles <- ControleReplay{Tipo: "pausar"}
+		case ev.Tecla == 'n' || ev.Tecla == 'N':
+			controles <- ControleReplay{Tipo: "passo"}
+		case ev.Tecla == '1' || ev.Tecla == '2' || ev.Tecla == '4':
+			controles <- ControleReplay{Tipo: "velocidade", Velocidade: int(ev.Tecla - '0')}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+// replay_test.go - Testes da gravação e reprodução de partidas
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// Reproduz o replay salvo em arquivo como o modo "jogo replay" e retorna o
+// último quadro
+func testeReproduzirReplay(t *testing.T, nome string) Jogo {
+	t.Helper()
+	replay, err := replayCarregar(nome)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tick, err := replayTick(replay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roteiro, err := replayRoteiro(replay)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := ConfigSimulacao{Intervalo: tick, Semente: replay.Semente, Deterministico: true}
+	jogo, err := carregarPartidaReplay(replay, &config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testeExecutarConfig(jogo, config, fonteRoteiroNova(roteiro))
+}
+
+// A reprodução de uma partida gravada termina no mesmo estado que a partida
+func TestReplayReproduzPartida(t *testing.T) {
+	roteiro, err := entradaLerRoteiro("testdata/simulacao_roteiro.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	jogo := testeCarregarMapa(t, "testdata/simulacao.txt")
+
+	gravacao := replayNovo("testdata/simulacao.txt", "", 42, IntervaloTickTeste)
+	config := ConfigSimulacao{Intervalo: IntervaloTickTeste, Semente: 42, Deterministico: true, Gravacao: gravacao}
+	original := testeExecutarConfig(jogoCopiar(&jogo), config, fonteRoteiroNova(roteiro))
+	if len(gravacao.Eventos) == 0 {
+		t.Fatal("a partida não gravou nenhum evento")
+	}
+
+	nome := filepath.Join(t.TempDir(), "replay.json")
+	if err := replaySalvar(gravacao, nome); err != nil {
+		t.Fatal(err)
+	}
+	testeCompararFinal(t, original, testeReproduzirReplay(t, nome))
+}
+
+// Compara o último quadro de uma partida com o da sua reprodução
+func testeCompararFinal(t *testing.T, original, reproduzida Jogo) {
+	t.Helper()
+
+	if !original.Tempo.Equal(reproduzida.Tempo) || original.Vida != reproduzida.Vida || original.Pontos != reproduzida.Pontos {
+		t.Errorf("reprodução terminou em %v com vida %d e %d pontos; a partida, em %v com vida %d e %d pontos",
+			reproduzida.Tempo, reproduzida.Vida, reproduzida.Pontos, original.Tempo, original.Vida, original.Pontos)
+	}
+	telaA, telaB := testeDesenharMemoria(original), testeDesenharMemoria(reproduzida)
+	if !reflect.DeepEqual(telaA.exibido, telaB.exibido) {
+		t.Errorf("reprodução terminou em outro quadro:\n%s\n---\n%s", telaA.Texto(), telaB.Texto())
+	}
+}
+
+// Uma partida que começou de um jogo salvo é reproduzida a partir da cópia
+// guardada no replay, mesmo que a tecla F5 tenha sobrescrito o arquivo
+func TestReplayDeJogoSalvo(t *testing.T) {
+	roteiro, err := entradaLerRoteiro("testdata/simulacao_roteiro.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Salva logo depois do primeiro passo e para antes da espera final do roteiro
+	roteiro = slices.Insert(roteiro[:len(roteiro)-1], 1, EventoTeclado{Tipo: "salvar", Tempo: roteiro[0].Tempo})
+
+	dir := t.TempDir()
+	salvamento := filepath.Join(dir, "salvamento.json")
+	mapa := testeCarregarMapa(t, "testdata/simulacao.txt")
+	mapa.Entidades[0].X++ // Começa em outra posição que a do mapa
+	if err := jogoSalvar(&mapa, salvamento); err != nil {
+		t.Fatal(err)
+	}
+	antes, _ := os.ReadFile(salvamento)
+
+	gravacao := replayNovo("testdata/simulacao.txt", salvamento, 42, IntervaloTickTeste)
+	config := ConfigSimulacao{Intervalo: IntervaloTickTeste, Semente: 42, Deterministico: true, Gravacao: gravacao, Salvamento: salvamento}
+	jogo, err := carregarPartida("testdata/simulacao.txt", "", salvamento, &config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	original := testeExecutarConfig(jogo, config, fonteRoteiroNova(roteiro))
+	if depois, _ := os.ReadFile(salvamento); bytes.Equal(antes, depois) {
+		t.Fatal("a tecla F5 não sobrescreveu o jogo salvo durante a partida")
+	}
+
+	nome := filepath.Join(dir, "replay.json")
+	if err := replaySalvar(gravacao, nome); err != nil {
+		t.Fatal(err)
+	}
+	testeCompararFinal(t, original, testeReproduzirReplay(t, nome))
+}
@@ -0,0 +1,658 @@
+// salvamento.go - Salvar e carregar o estado completo do jogo
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Versão do formato do arquivo de salvamento
+// Deve ser incrementada sempre que JogoSalvo mudar de forma incompatível
+const VersaoSalvamento = 1
+
+// Arquivo padrão onde a tecla F5 salva o jogo
+const ArquivoSalvamentoPadrao = "salvamento.json"
+
+// JogoSalvo é a forma serializável do estado do jogo
+// Elementos são guardados pelo seu símbolo e os instantes (bombas, explosões e
+// último dano) pelo tempo que ainda lhes resta, pois o relógio da partida
+// carregada começa de novo
+type JogoSalvo struct {
+	Versao        int                `json:"versao"`
+	Mapa          []string           `json:"mapa"` // uma linha de símbolos por linha do mapa
+	Direcao       string             `json:"direcao"`
+	StatusMsg     string             `json:"status"`
+	Entidades     []EntidadeSalva    `json:"entidades"` // [0] é o personagem
+	ProximoID     int                `json:"proximo_id"`
+	LogsInimigos  map[int]string     `json:"logs_inimigos"`
+	Vida          int                `json:"vida"`
+	Invulneravel  string             `json:"invulneravel"` // tempo restante sem receber dano
+	CuraUsada     bool               `json:"cura_usada"`
+	Bombas        []TemporizadoSalvo `json:"bombas"`
+	Explosoes     []TemporizadoSalvo `json:"explosoes"`
+	Projeteis     []ProjetilSalvo    `json:"projeteis,omitempty"`
+	Ninhos        []NinhoSalvo       `json:"ninhos,omitempty"`
+	Chamas        []TemporizadoSalvo `json:"chamas,omitempty"`
+	JogoTerminado bool               `json:"jogo_terminado"`
+	Venceu        bool               `json:"venceu,omitempty"`
+	Pontos        int                `json:"pontos,omitempty"`
+	FormaBomba    string             `json:"forma_bomba,omitempty"`    // forma das próximas bombas
+	BombasMochila *int               `json:"bombas_mochila,omitempty"` // ausente: mochila cheia
+	ProximaBomba  string             `json:"proxima_bomba,omitempty"`  // tempo restante da recarga da próxima bomba
+	Cabecalho     *CabecalhoSalvo    `json:"cabecalho,omitempty"`      // ausente: regras padrão
+	Campanha      *CampanhaSalva     `json:"campanha,omitempty"`       // ausente: mapa avulso
+	Introducao    string             `json:"introducao,omitempty"`     // tempo restante da apresentação do nível
+}
+
+// CabecalhoSalvo é a forma serializável do cabeçalho do mapa
+type CabecalhoSalvo struct {
+	Nome               string `json:"nome,omitempty"`
+	Autor              string `json:"autor,omitempty"`
+	VidaMaxima         int    `json:"vida_maxima"`
+	VelocidadeInimigos string `json:"velocidade_inimigos"` // ex.: "500ms"
+	RaioBomba          int    `json:"raio_bomba"`
+	Vitoria            string `json:"vitoria"`
+	IntervaloNinhos    string `json:"intervalo_ninhos,omitempty"` // ausente: padrão
+	LimiteNinhos       int    `json:"limite_ninhos,omitempty"`
+	InimigoNinhos      string `json:"inimigo_ninhos,omitempty"`
+	FormaBomba         string `json:"forma_bomba,omitempty"`
+	FogoAmigo          *bool  `json:"fogo_amigo,omitempty"` // ausente: ligado
+	CapacidadeBombas   int    `json:"bombas,omitempty"`
+	RecargaBomba       string `json:"recarga_bomba,omitempty"`
+}
+
+// EntidadeSalva é a forma serializável de uma Entidade
+type EntidadeSalva struct {
+	ID             int         `json:"id"`
+	Sprite         string      `json:"sprite"`
+	X              int         `json:"x"`
+	Y              int         `json:"y"`
+	UltimoVisitado string      `json:"ultimo_visitado"`
+	Rota           *RotaSalva  `json:"rota,omitempty"`  // ausente: anda ao acaso
+	Tipo           string      `json:"tipo,omitempty"`  // tipo do inimigo (ausente: comum)
+	Vida           int         `json:"vida,omitempty"`  // explosões que o inimigo ainda aguenta
+	Ninho          int         `json:"ninho,omitempty"` // ninho que gerou o inimigo
+	Mente          *MenteSalva `json:"mente,omitempty"` // ausente: patrulhando
+}
+
+// MenteSalva é a forma serializável da memória de um inimigo
+// Os prazos são guardados pelo tempo que ainda falta; o caminho até o alvo
+// não é salvo, pois o inimigo o calcula de novo
+type MenteSalva struct {
+	Estado         string `json:"estado"`                    // veja estadosPorNome
+	Alvo           [2]int `json:"alvo"`                      // última posição conhecida do personagem
+	Posto          [2]int `json:"posto"`                     // onde o inimigo deixou a patrulha
+	Procura        string `json:"procura,omitempty"`         // tempo restante da procura
+	ProximoDisparo string `json:"proximo_disparo,omitempty"` // tempo restante da recarga da arma
+	ProximoAtaque  string `json:"proximo_ataque,omitempty"`  // tempo restante da recarga do ataque do chefe
+}
+
+// RotaSalva é a forma serializável da rota de patrulha de um inimigo
+type RotaSalva struct {
+	Modo   string   `json:"modo"`
+	Pontos [][2]int `json:"pontos"`          // pares [x, y]
+	Etapa  int      `json:"etapa,omitempty"` // índice do próximo ponto
+	Volta  bool     `json:"volta,omitempty"` // rota vaivem percorrida do fim para o começo
+}
+
+// CampanhaSalva guarda o nível da campanha em andamento
+// O manifesto é lido de novo ao carregar, para continuar nos próximos níveis
+type CampanhaSalva struct {
+	Arquivo   string `json:"arquivo"`
+	Nome      string `json:"nome"`
+	NomeNivel string `json:"nome_nivel"`
+	Nivel     int    `json:"nivel"`
+	Total     int    `json:"total"`
+	Concluida bool   `json:"concluida,omitempty"`
+}
+
+// NinhoSalvo é a forma serializável de um ninho
+// A célula do mapa guarda o símbolo; aqui fica o ID, ligado aos inimigos gerados
+type NinhoSalvo struct {
+	ID      int    `json:"id"`
+	X       int    `json:"x"`
+	Y       int    `json:"y"`
+	Proximo string `json:"proximo,omitempty"` // tempo restante até a próxima geração (ausente: um intervalo)
+}
+
+// ProjetilSalvo é a forma serializável de um projétil em voo
+type ProjetilSalvo struct {
+	ID   int `json:"id"`
+	X    int `json:"x"`
+	Y    int `json:"y"`
+	DX   int `json:"dx"`
+	DY   int `json:"dy"`
+	Dano int `json:"dano"`
+}
+
+// TemporizadoSalvo guarda uma bomba, explosão ou chama e o tempo que lhe resta
+type TemporizadoSalvo struct {
+	X        int    `json:"x"`
+	Y        int    `json:"y"`
+	Restante string `json:"restante"`        // ex.: "1.2s"
+	Forma    string `json:"forma,omitempty"` // forma da explosão da bomba (ausente: losango)
+	Pisao    bool   `json:"pisao,omitempty"` // explosão do pisão do chefe
+}
+
+// Elementos que podem aparecer no mapa ou como sprite, pelo símbolo
+var elementosPorSimbolo = map[rune]Elemento{
+	Personagem.simbolo:   Personagem,
+	Inimigo.simbolo:      Inimigo,
+	Parede.simbolo:       Parede,
+	Vegetacao.simbolo:    Vegetacao,
+	Vazio.simbolo:        Vazio,
+	Cura.simbolo:         Cura,
+	NinhoElem.simbolo:    NinhoElem,
+	ParedeFragil.simbolo: ParedeFragil,
+	FogoElem.simbolo:     FogoElem,
+}
+
+// Estados da máquina de estados dos inimigos, pelo nome salvo
+var estadosPorNome = map[string]EstadoInimigo{
+	"patrulha":    EstadoPatrulha,
+	"alerta":      EstadoAlerta,
+	"perseguindo": EstadoPerseguindo,
+	"procurando":  EstadoProcurando,
+	"retornando":  EstadoRetornando,
+}
+
+// ============================================================================
+// MÓDULO DE SALVAMENTO
+// ============================================================================
+
+// Salva o estado completo do jogo em um arquivo JSON
+func jogoSalvar(jogo *Jogo, nome string) error {
+	salvo := JogoSalvo{
+		Versao:        VersaoSalvamento,
+		Direcao:       string(jogo.Direcao),
+		StatusMsg:     jogo.StatusMsg,
+		ProximoID:     jogo.ProximoID,
+		LogsInimigos:  jogo.LogsInimigos,
+		Vida:          jogo.Vida,
+		Invulneravel:  salvamentoRestante(jogo, jogo.UltimoDano, IntervaloDano).String(),
+		CuraUsada:     jogo.CuraUsada,
+		JogoTerminado: jogo.JogoTerminado,
+		Venceu:        jogo.Venceu,
+		Pontos:        jogo.Pontos,
+		FormaBomba:    jogo.FormaBomba,
+		BombasMochila: &jogo.BombasMochila,
+		Cabecalho: &CabecalhoSalvo{
+			Nome:               jogo.Cabecalho.Nome,
+			Autor:              jogo.Cabecalho.Autor,
+			VidaMaxima:         jogo.Cabecalho.VidaMaxima,
+			VelocidadeInimigos: jogo.Cabecalho.IntervaloInimigo.String(),
+			RaioBomba:          jogo.Cabecalho.RaioBomba,
+			Vitoria:            jogo.Cabecalho.Vitoria,
+			IntervaloNinhos:    jogo.Cabecalho.IntervaloNinhos.String(),
+			LimiteNinhos:       jogo.Cabecalho.LimiteNinhos,
+			InimigoNinhos:      jogo.Cabecalho.InimigoNinhos,
+			FormaBomba:         jogo.Cabecalho.FormaBomba,
+			FogoAmigo:          &jogo.Cabecalho.FogoAmigo,
+			CapacidadeBombas:   jogo.Cabecalho.CapacidadeBombas,
+			RecargaBomba:       jogo.Cabecalho.RecargaBomba.String(),
+		},
+	}
+
+	if jogo.Campanha.Total > 0 {
+		salvo.Campanha = &CampanhaSalva{
+			Arquivo:   jogo.Campanha.Arquivo,
+			Nome:      jogo.Campanha.Nome,
+			NomeNivel: jogo.Campanha.NomeNivel,
+			Nivel:     jogo.Campanha.Nivel,
+			Total:     jogo.Campanha.Total,
+			Concluida: jogo.Campanha.Concluida,
+		}
+	}
+	if jogoEmIntroducao(jogo) {
+		salvo.Introducao = jogo.IntroducaoAte.Sub(jogo.Tempo).String()
+	}
+	if !jogo.RecargaDesde.IsZero() {
+		salvo.ProximaBomba = salvamentoRestante(jogo, jogo.RecargaDesde, jogo.Cabecalho.RecargaBomba).String()
+	}
+
+	for _, linha := range jogo.Mapa {
+		simbolos := make([]rune, len(linha))
+		for x, elem := range linha {
+			simbolos[x] = elem.simbolo
+		}
+		salvo.Mapa = append(salvo.Mapa, string(simbolos))
+	}
+
+	for _, ent := range jogo.Entidades {
+		salva := EntidadeSalva{
+			ID:             ent.ID,
+			Sprite:         string(ent.Sprite.simbolo),
+			X:              ent.X,
+			Y:              ent.Y,
+			UltimoVisitado: string(ent.UltimoVisitado.simbolo),
+			Tipo:           ent.Tipo,
+			Vida:           ent.Vida,
+			Ninho:          ent.Ninho,
+		}
+		if ent.Rota != nil {
+			salva.Rota = &RotaSalva{Modo: ent.Rota.Modo, Etapa: ent.Mente.Etapa, Volta: ent.Mente.Volta}
+			for _, p := range ent.Rota.Pontos {
+				salva.Rota.Pontos = append(salva.Rota.Pontos, [2]int{p.X, p.Y})
+			}
+		}
+		salva.Mente = salvamentoMente(jogo, ent.Mente)
+		salvo.Entidades = append(salvo.Entidades, salva)
+	}
+
+	for _, bomba := range jogo.Bombas {
+		if bomba.Ativa {
+			restante := salvamentoRestante(jogo, bomba.TempoVida, DuracaoBomba)
+			salvo.Bombas = append(salvo.Bombas, TemporizadoSalvo{X: bomba.X, Y: bomba.Y, Restante: restante.String(), Forma: bomba.Forma})
+		}
+	}
+	for _, explosao := range jogo.Explosoes {
+		if explosao.Ativa {
+			restante := salvamentoRestante(jogo, explosao.TempoVida, DuracaoExplosao)
+			salvo.Explosoes = append(salvo.Explosoes, TemporizadoSalvo{X: explosao.X, Y: explosao.Y, Restante: restante.String(), Pisao: explosao.Pisao})
+		}
+	}
+
+	for _, p := range jogo.Projeteis {
+		salvo.Projeteis = append(salvo.Projeteis, ProjetilSalvo(p))
+	}
+	for _, n := range jogo.Ninhos {
+		ninho := NinhoSalvo{ID: n.ID, X: n.X, Y: n.Y}
+		if !n.Proximo.IsZero() {
+			ninho.Proximo = salvamentoRestante(jogo, n.Proximo, 0).String()
+		}
+		salvo.Ninhos = append(salvo.Ninhos, ninho)
+	}
+	for _, c := range jogo.Chamas {
+		restante := salvamentoRestante(jogo, c.Inicio, DuracaoChama)
+		salvo.Chamas = append(salvo.Chamas, TemporizadoSalvo{X: c.X, Y: c.Y, Restante: restante.String()})
+	}
+
+	dados, err := json.MarshalIndent(salvo, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(nome, dados, 0644)
+}
+
+// Converte a memória de um inimigo para a forma serializável
+// Retorna nil para quem patrulha sem nenhuma recarga pendente, como o
+// personagem. Os prazos são instantes de duração zero para salvamentoRestante
+func salvamentoMente(jogo *Jogo, mente MenteInimigo) *MenteSalva {
+	if mente.Estado == EstadoPatrulha && !mente.ProximoDisparo.After(jogo.Tempo) && !mente.ProximoAtaque.After(jogo.Tempo) {
+		return nil
+	}
+	salva := &MenteSalva{
+		Alvo:  [2]int{mente.Alvo.X, mente.Alvo.Y},
+		Posto: [2]int{mente.Posto.X, mente.Posto.Y},
+	}
+	for nome, estado := range estadosPorNome {
+		if estado == mente.Estado {
+			salva.Estado = nome
+		}
+	}
+	if mente.Estado == EstadoProcurando {
+		salva.Procura = salvamentoRestante(jogo, mente.Prazo, 0).String()
+	}
+	if mente.ProximoDisparo.After(jogo.Tempo) {
+		salva.ProximoDisparo = salvamentoRestante(jogo, mente.ProximoDisparo, 0).String()
+	}
+	if mente.ProximoAtaque.After(jogo.Tempo) {
+		salva.ProximoAtaque = salvamentoRestante(jogo, mente.ProximoAtaque, 0).String()
+	}
+	return salva
+}
+
+// Calcula quanto falta para terminar algo iniciado em inicio com a duração informada
+func salvamentoRestante(jogo *Jogo, inicio time.Time, duracao time.Duration) time.Duration {
+	if inicio.IsZero() {
+		return 0
+	}
+	restante := duracao - jogo.Tempo.Sub(inicio)
+	if restante < 0 {
+		return 0
+	}
+	return restante
+}
+
+// ============================================================================
+// MÓDULO DE CARREGAMENTO
+// ============================================================================
+
+// Carrega um jogo salvo por jogoSalvar
+// Os instantes são reconstruídos a partir do tempo restante, com jogo.Tempo
+// como referência; a simulação os desloca para o seu relógio ao começar
+func jogoCarregar(nome string) (Jogo, error) {
+	dados, err := os.ReadFile(nome)
+	if err != nil {
+		return Jogo{}, err
+	}
+	return jogoInterpretarSalvamento(nome, dados)
+}
+
+// Interpreta o conteúdo de um jogo salvo, lido de um arquivo ou guardado
+// em um replay. O nome identifica a origem nas mensagens de erro
+func jogoInterpretarSalvamento(nome string, dados []byte) (Jogo, error) {
+	var salvo JogoSalvo
+	if err := json.Unmarshal(dados, &salvo); err != nil {
+		return Jogo{}, fmt.Errorf("%s: salvamento inválido: %v", nome, err)
+	}
+	if salvo.Versao != VersaoSalvamento {
+		return Jogo{}, fmt.Errorf("%s: versão de salvamento %d não suportada (esperada %d)", nome, salvo.Versao, VersaoSalvamento)
+	}
+	if len(salvo.Entidades) == 0 {
+		return Jogo{}, fmt.Errorf("%s: salvamento sem personagem", nome)
+	}
+
+	jogo := jogoNovo()
+	jogo.StatusMsg = "Jogo carregado"
+	jogo.ProximoID = salvo.ProximoID
+	jogo.Vida = salvo.Vida
+	jogo.CuraUsada = salvo.CuraUsada
+	jogo.JogoTerminado = salvo.JogoTerminado
+	jogo.Venceu = salvo.Venceu
+	jogo.Pontos = salvo.Pontos
+	if salvo.FormaBomba != "" {
+		if !explosaoFormaValida(salvo.FormaBomba) {
+			return Jogo{}, fmt.Errorf("%s: forma de bomba desconhecida %q", nome, salvo.FormaBomba)
+		}
+		jogo.FormaBomba = salvo.FormaBomba
+	}
+	if direcao := []rune(salvo.Direcao); len(direcao) == 1 {
+		jogo.Direcao = direcao[0]
+	}
+	for id, log := range salvo.LogsInimigos {
+		jogo.LogsInimigos[id] = log
+	}
+	if cab := salvo.Cabecalho; cab != nil {
+		intervalo, err := time.ParseDuration(cab.VelocidadeInimigos)
+		if err != nil || intervalo <= 0 || cab.VidaMaxima <= 0 || cab.RaioBomba <= 0 {
+			return Jogo{}, fmt.Errorf("%s: cabeçalho do mapa inválido", nome)
+		}
+		jogo.Cabecalho = CabecalhoMapa{
+			Nome:             cab.Nome,
+			Autor:            cab.Autor,
+			VidaMaxima:       cab.VidaMaxima,
+			IntervaloInimigo: intervalo,
+			RaioBomba:        cab.RaioBomba,
+			Vitoria:          cab.Vitoria,
+			IntervaloNinhos:  IntervaloNinhoPadrao,
+			LimiteNinhos:     LimiteNinhoPadrao,
+			InimigoNinhos:    TipoInimigo,
+			FormaBomba:       FormaLosango,
+			FogoAmigo:        true,
+			CapacidadeBombas: CapacidadeBombasPadrao,
+			RecargaBomba:     RecargaBombaPadrao,
+		}
+		if cab.FogoAmigo != nil {
+			jogo.Cabecalho.FogoAmigo = *cab.FogoAmigo
+		}
+		if cab.IntervaloNinhos != "" {
+			intervalo, err := time.ParseDuration(cab.IntervaloNinhos)
+			if err != nil || intervalo <= 0 {
+				return Jogo{}, fmt.Errorf("%s: cabeçalho do mapa inválido", nome)
+			}
+			jogo.Cabecalho.IntervaloNinhos = intervalo
+		}
+		if cab.LimiteNinhos > 0 {
+			jogo.Cabecalho.LimiteNinhos = cab.LimiteNinhos
+		}
+		if cab.CapacidadeBombas > 0 {
+			jogo.Cabecalho.CapacidadeBombas = cab.CapacidadeBombas
+		}
+		if cab.RecargaBomba != "" {
+			recarga, err := time.ParseDuration(cab.RecargaBomba)
+			if err != nil || recarga <= 0 {
+				return Jogo{}, fmt.Errorf("%s: cabeçalho do mapa inválido", nome)
+			}
+			jogo.Cabecalho.RecargaBomba = recarga
+		}
+		if cab.FormaBomba != "" {
+			if !explosaoFormaValida(cab.FormaBomba) {
+				return Jogo{}, fmt.Errorf("%s: cabeçalho do mapa inválido", nome)
+			}
+			jogo.Cabecalho.FormaBomba = cab.FormaBomba
+		}
+		if cab.InimigoNinhos != "" {
+			if !arquetipoEhInimigo(cab.InimigoNinhos) || cab.InimigoNinhos == TipoChefe {
+				return Jogo{}, fmt.Errorf("%s: cabeçalho do mapa inválido", nome)
+			}
+			jogo.Cabecalho.InimigoNinhos = cab.InimigoNinhos
+		}
+	}
+
+	for y, linha := range salvo.Mapa {
+		var linhaElems []Elemento
+		for x, simbolo := range []rune(linha) {
+			elem, err := salvamentoElemento(string(simbolo))
+			if err != nil {
+				return Jogo{}, fmt.Errorf("%s: mapa, linha %d, coluna %d: %v", nome, y+1, x+1, err)
+			}
+			linhaElems = append(linhaElems, elem)
+		}
+		jogo.Mapa = append(jogo.Mapa, linhaElems)
+	}
+
+	for _, salva := range salvo.Entidades {
+		sprite, err := salvamentoElemento(salva.Sprite)
+		if err != nil {
+			return Jogo{}, fmt.Errorf("%s: entidade %d: %v", nome, salva.ID, err)
+		}
+		visitado, err := salvamentoElemento(salva.UltimoVisitado)
+		if err != nil {
+			return Jogo{}, fmt.Errorf("%s: entidade %d: %v", nome, salva.ID, err)
+		}
+		ent := Entidade{
+			ID:             salva.ID,
+			Sprite:         sprite,
+			X:              salva.X,
+			Y:              salva.Y,
+			UltimoVisitado: visitado,
+			Tipo:           salva.Tipo,
+			Vida:           salva.Vida,
+			Ninho:          salva.Ninho,
+		}
+		if salva.Tipo != "" && !arquetipoEhInimigo(salva.Tipo) {
+			return Jogo{}, fmt.Errorf("%s: entidade %d: tipo de inimigo desconhecido %q", nome, salva.ID, salva.Tipo)
+		}
+		t := arquetipoTamanho(ent)
+		if !salvamentoNoMapa(&jogo, ent.X, ent.Y) || !salvamentoNoMapa(&jogo, ent.X+t-1, ent.Y+t-1) {
+			return Jogo{}, fmt.Errorf("%s: entidade %d em (%d, %d) fora do mapa", nome, salva.ID, salva.X, salva.Y)
+		}
+		if salva.Mente != nil {
+			mente, err := salvamentoCarregarMente(&jogo, salva.Mente)
+			if err != nil {
+				return Jogo{}, fmt.Errorf("%s: entidade %d: %v", nome, salva.ID, err)
+			}
+			ent.Mente = mente
+		}
+		if r := salva.Rota; r != nil {
+			if (r.Modo != RotaCiclo && r.Modo != RotaVaiVem) || len(r.Pontos) < 2 || r.Etapa < 0 || r.Etapa >= len(r.Pontos) {
+				return Jogo{}, fmt.Errorf("%s: entidade %d: rota inválida", nome, salva.ID)
+			}
+			ent.Rota = &Rota{Modo: r.Modo}
+			ent.Mente.Etapa, ent.Mente.Volta = r.Etapa, r.Volta
+			for _, p := range r.Pontos {
+				if !salvamentoNoMapa(&jogo, p[0], p[1]) {
+					return Jogo{}, fmt.Errorf("%s: entidade %d: ponto da rota (%d, %d) fora do mapa", nome, salva.ID, p[0], p[1])
+				}
+				ent.Rota.Pontos = append(ent.Rota.Pontos, Ponto{p[0], p[1]})
+			}
+		}
+		jogo.Entidades = append(jogo.Entidades, ent)
+	}
+
+	if c := salvo.Campanha; c != nil {
+		jogo.Campanha = InfoCampanha{
+			Arquivo:   c.Arquivo,
+			Nome:      c.Nome,
+			NomeNivel: c.NomeNivel,
+			Nivel:     c.Nivel,
+			Total:     c.Total,
+			Concluida: c.Concluida,
+		}
+	}
+	if salvo.Introducao != "" {
+		introducao, err := time.ParseDuration(salvo.Introducao)
+		if err != nil {
+			return Jogo{}, fmt.Errorf("%s: apresentação do nível inválida: %v", nome, err)
+		}
+		jogo.IntroducaoAte = jogo.Tempo.Add(introducao)
+	}
+
+	invulneravel, err := time.ParseDuration(salvo.Invulneravel)
+	if err != nil {
+		return Jogo{}, fmt.Errorf("%s: invulnerabilidade inválida: %v", nome, err)
+	}
+	if invulneravel > 0 {
+		jogo.UltimoDano = jogo.Tempo.Add(invulneravel - IntervaloDano)
+	}
+
+	jogo.BombasMochila = jogo.Cabecalho.CapacidadeBombas
+	if salvo.BombasMochila != nil {
+		if *salvo.BombasMochila < 0 || *salvo.BombasMochila > jogo.Cabecalho.CapacidadeBombas {
+			return Jogo{}, fmt.Errorf("%s: bombas na mochila fora da capacidade: %d", nome, *salvo.BombasMochila)
+		}
+		jogo.BombasMochila = *salvo.BombasMochila
+	}
+	if jogo.BombasMochila < jogo.Cabecalho.CapacidadeBombas {
+		if salvo.ProximaBomba == "" {
+			return Jogo{}, fmt.Errorf("%s: mochila incompleta sem tempo de recarga", nome)
+		}
+		inicio, err := salvamentoInicio(&jogo, salvo.ProximaBomba, jogo.Cabecalho.RecargaBomba)
+		if err != nil {
+			return Jogo{}, fmt.Errorf("%s: recarga da bomba: %v", nome, err)
+		}
+		jogo.RecargaDesde = inicio
+	}
+
+	for _, salva := range salvo.Bombas {
+		inicio, err := salvamentoInicio(&jogo, salva.Restante, DuracaoBomba)
+		if err != nil {
+			return Jogo{}, fmt.Errorf("%s: bomba em (%d, %d): %v", nome, salva.X, salva.Y, err)
+		}
+		if !salvamentoNoMapa(&jogo, salva.X, salva.Y) {
+			return Jogo{}, fmt.Errorf("%s: bomba em (%d, %d) fora do mapa", nome, salva.X, salva.Y)
+		}
+		if salva.Forma != "" && !explosaoFormaValida(salva.Forma) {
+			return Jogo{}, fmt.Errorf("%s: bomba em (%d, %d): forma desconhecida %q", nome, salva.X, salva.Y, salva.Forma)
+		}
+		jogo.Bombas = append(jogo.Bombas, Bomba{X: salva.X, Y: salva.Y, TempoVida: inicio, Ativa: true, Forma: salva.Forma})
+	}
+	for _, salva := range salvo.Explosoes {
+		inicio, err := salvamentoInicio(&jogo, salva.Restante, DuracaoExplosao)
+		if err != nil {
+			return Jogo{}, fmt.Errorf("%s: explosão em (%d, %d): %v", nome, salva.X, salva.Y, err)
+		}
+		if !salvamentoNoMapa(&jogo, salva.X, salva.Y) {
+			return Jogo{}, fmt.Errorf("%s: explosão em (%d, %d) fora do mapa", nome, salva.X, salva.Y)
+		}
+		jogo.Explosoes = append(jogo.Explosoes, Explosao{X: salva.X, Y: salva.Y, TempoVida: inicio, Ativa: true, Pisao: salva.Pisao})
+	}
+	for _, salva := range salvo.Projeteis {
+		if abs(salva.DX)+abs(salva.DY) != 1 {
+			return Jogo{}, fmt.Errorf("%s: projétil %d: direção inválida", nome, salva.ID)
+		}
+		if !salvamentoNoMapa(&jogo, salva.X, salva.Y) {
+			return Jogo{}, fmt.Errorf("%s: projétil %d em (%d, %d) fora do mapa", nome, salva.ID, salva.X, salva.Y)
+		}
+		jogo.Projeteis = append(jogo.Projeteis, Projetil(salva))
+	}
+	for _, salvo := range salvo.Ninhos {
+		if !salvamentoNoMapa(&jogo, salvo.X, salvo.Y) || jogo.Mapa[salvo.Y][salvo.X].simbolo != NinhoElem.simbolo {
+			return Jogo{}, fmt.Errorf("%s: ninho %d fora de um ninho do mapa", nome, salvo.ID)
+		}
+		ninho := Ninho{ID: salvo.ID, X: salvo.X, Y: salvo.Y}
+		if salvo.Proximo != "" {
+			proximo, err := salvamentoInicio(&jogo, salvo.Proximo, 0)
+			if err != nil {
+				return Jogo{}, fmt.Errorf("%s: ninho %d: %v", nome, salvo.ID, err)
+			}
+			ninho.Proximo = proximo
+		}
+		jogo.Ninhos = append(jogo.Ninhos, ninho)
+	}
+	for _, salva := range salvo.Chamas {
+		inicio, err := salvamentoInicio(&jogo, salva.Restante, DuracaoChama)
+		if err != nil {
+			return Jogo{}, fmt.Errorf("%s: chama em (%d, %d): %v", nome, salva.X, salva.Y, err)
+		}
+		if !salvamentoNoMapa(&jogo, salva.X, salva.Y) || jogo.Mapa[salva.Y][salva.X].simbolo != FogoElem.simbolo {
+			return Jogo{}, fmt.Errorf("%s: chama em (%d, %d) fora do fogo do mapa", nome, salva.X, salva.Y)
+		}
+		jogo.Chamas = append(jogo.Chamas, Chama{X: salva.X, Y: salva.Y, Inicio: inicio})
+	}
+
+	return jogo, nil
+}
+
+// Reconstrói a memória de um inimigo salva por salvamentoMente
+func salvamentoCarregarMente(jogo *Jogo, salva *MenteSalva) (MenteInimigo, error) {
+	estado, ok := estadosPorNome[salva.Estado]
+	if !ok {
+		return MenteInimigo{}, fmt.Errorf("estado desconhecido %q", salva.Estado)
+	}
+	mente := MenteInimigo{
+		Estado: estado,
+		Alvo:   Ponto{salva.Alvo[0], salva.Alvo[1]},
+		Posto:  Ponto{salva.Posto[0], salva.Posto[1]},
+	}
+	for _, p := range []Ponto{mente.Alvo, mente.Posto} {
+		if !salvamentoNoMapa(jogo, p.X, p.Y) {
+			return MenteInimigo{}, fmt.Errorf("ponto da memória (%d, %d) fora do mapa", p.X, p.Y)
+		}
+	}
+
+	prazos := []struct {
+		restante string
+		instante *time.Time
+	}{
+		{salva.Procura, &mente.Prazo},
+		{salva.ProximoDisparo, &mente.ProximoDisparo},
+		{salva.ProximoAtaque, &mente.ProximoAtaque},
+	}
+	for _, p := range prazos {
+		if p.restante == "" {
+			continue
+		}
+		instante, err := salvamentoInicio(jogo, p.restante, 0)
+		if err != nil {
+			return MenteInimigo{}, err
+		}
+		*p.instante = instante
+	}
+	return mente, nil
+}
+
+// Indica se a posição (x, y) está dentro do mapa carregado
+// Posições de um salvamento editado à mão podem apontar para fora dele
+func salvamentoNoMapa(jogo *Jogo, x, y int) bool {
+	return y >= 0 && y < len(jogo.Mapa) && x >= 0 && x < len(jogo.Mapa[y])
+}
+
+// Reconstrói o instante de início a partir do tempo restante salvo
+func salvamentoInicio(jogo *Jogo, restante string, duracao time.Duration) (time.Time, error) {
+	r, err := time.ParseDuration(restante)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("tempo restante inválido: %v", err)
+	}
+	return jogo.Tempo.Add(r - duracao), nil
+}
+
+// Converte um símbolo salvo no elemento correspondente
+func salvamentoElemento(simbolo string) (Elemento, error) {
+	simbolos := []rune(simbolo)
+	if len(simbolos) != 1 {
+		return Elemento{}, fmt.Errorf("símbolo inválido %q", simbolo)
+	}
+	if elem, ok := elementosPorSimbolo[simbolos[0]]; ok {
+		return elem, nil
+	}
+	for _, a := range arquetiposInimigo {
+		if a.Sprite.simbolo == simbolos[0] {
+			return a.Sprite, nil
+		}
+	}
+	return Elemento{}, fmt.Errorf("símbolo desconhecido %q", simbolo)
+}
@@ -0,0 +1,134 @@
+// salvamento_test.go - Testes do salvamento e carregamento do jogo
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Salva o jogo em um arquivo temporário e o relê como JSON genérico,
+// para que o teste possa editá-lo como alguém faria à mão
+func testeSalvarJSON(t *testing.T, jogo *Jogo) map[string]any {
+	t.Helper()
+	arquivo := filepath.Join(t.TempDir(), "salvamento.json")
+	if err := jogoSalvar(jogo, arquivo); err != nil {
+		t.Fatal(err)
+	}
+	dados, err := os.ReadFile(arquivo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var salvo map[string]any
+	if err := json.Unmarshal(dados, &salvo); err != nil {
+		t.Fatal(err)
+	}
+	return salvo
+}
+
+// Grava o JSON editado e tenta carregá-lo
+func testeCarregarJSON(t *testing.T, salvo map[string]any) (Jogo, error) {
+	t.Helper()
+	dados, err := json.Marshal(salvo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	arquivo := filepath.Join(t.TempDir(), "editado.json")
+	if err := os.WriteFile(arquivo, dados, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return jogoCarregar(arquivo)
+}
+
+// Posições fora do mapa em um salvamento editado são recusadas com erro,
+// em vez de derrubarem o jogo quando a simulação as usa
+func TestCarregarRecusaPosicoesForaDoMapa(t *testing.T) {
+	casos := []struct {
+		nome   string
+		editar func(salvo map[string]any)
+	}{
+		{"bomba", func(salvo map[string]any) {
+			salvo["bombas"] = []any{map[string]any{"x": 999, "y": 2, "restante": "1s", "forma": "cruz"}}
+		}},
+		{"explosão", func(salvo map[string]any) {
+			salvo["explosoes"] = []any{map[string]any{"x": 1, "y": -1, "restante": "100ms"}}
+		}},
+		{"projétil", func(salvo map[string]any) {
+			salvo["projeteis"] = []any{map[string]any{"id": 50, "x": 40, "y": 1, "dx": 1, "dy": 0}}
+		}},
+		{"entidade", func(salvo map[string]any) {
+			salvo["entidades"].([]any)[1].(map[string]any)["x"] = 11
+		}},
+		{"memória do inimigo", func(salvo map[string]any) {
+			salvo["entidades"].([]any)[1].(map[string]any)["mente"] = map[string]any{"estado": "procurando", "alvo": []int{3, 20}, "posto": []int{8, 2}}
+		}},
+	}
+
+	jogo := testeCarregarMapa(t, "testdata/programada.txt")
+	for _, caso := range casos {
+		salvo := testeSalvarJSON(t, &jogo)
+		caso.editar(salvo)
+		if _, err := testeCarregarJSON(t, salvo); err == nil || !strings.Contains(err.Error(), "fora do mapa") {
+			t.Errorf("%s fora do mapa: erro = %v, esperado \"fora do mapa\"", caso.nome, err)
+		}
+	}
+
+	// O salvamento sem edição continua válido
+	if _, err := testeCarregarJSON(t, testeSalvarJSON(t, &jogo)); err != nil {
+		t.Errorf("salvamento sem edição recusado: %v", err)
+	}
+}
+
+// A memória do inimigo (estado, alvo, prazo da procura, recargas e o ponto
+// da rota para onde ia) volta igual ao carregar, com os prazos contados a
+// partir do novo relógio
+func TestCarregarMemoriaInimigo(t *testing.T) {
+	jogo := testeCarregarMapa(t, "testdata/programada.txt")
+	mente := MenteInimigo{
+		Estado:         EstadoProcurando,
+		Alvo:           Ponto{3, 2},
+		Posto:          Ponto{8, 2},
+		Prazo:          jogo.Tempo.Add(3 * time.Second),
+		ProximoDisparo: jogo.Tempo.Add(time.Second),
+		ProximoAtaque:  jogo.Tempo.Add(-time.Second), // já recarregado
+		Etapa:          1,
+		Volta:          true,
+	}
+	jogo.Entidades[1].Mente = mente
+	jogo.Entidades[1].Rota = &Rota{Modo: RotaVaiVem, Pontos: []Ponto{{1, 1}, {5, 1}, {5, 3}}}
+
+	carregado, err := testeCarregarJSON(t, testeSalvarJSON(t, &jogo))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mente.ProximoAtaque = time.Time{} // Recargas vencidas não são salvas
+	if got := carregado.Entidades[1].Mente; got.Estado != mente.Estado || got.Alvo != mente.Alvo || got.Posto != mente.Posto ||
+		!got.Prazo.Equal(mente.Prazo) || !got.ProximoDisparo.Equal(mente.ProximoDisparo) || !got.ProximoAtaque.Equal(mente.ProximoAtaque) ||
+		got.Etapa != mente.Etapa || got.Volta != mente.Volta {
+		t.Errorf("memória carregada = %+v, esperada %+v", got, mente)
+	}
+	if got := carregado.Entidades[0].Mente; got.Estado != EstadoPatrulha {
+		t.Errorf("personagem carregado com memória de inimigo: %+v", got)
+	}
+}
+
+// O ninho continua contando o intervalo até a próxima geração de onde parou
+func TestCarregarProximaGeracaoNinho(t *testing.T) {
+	jogo := testeCarregarMapa(t, "testdata/simulacao.txt")
+	if len(jogo.Ninhos) == 0 {
+		t.Fatal("mapa de teste sem ninho")
+	}
+	proximo := jogo.Tempo.Add(4 * time.Second)
+	jogo.Ninhos[0].Proximo = proximo
+
+	carregado, err := testeCarregarJSON(t, testeSalvarJSON(t, &jogo))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := carregado.Ninhos[0].Proximo; !got.Equal(proximo) {
+		t.Errorf("próxima geração do ninho carregada = %v, esperada %v", got, proximo)
+	}
+}
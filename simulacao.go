@@ -0,0 +1,663 @@
+// simulacao.go - Goroutine dona do estado do jogo e relógio da simulação
+package main
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// Intervalo padrão entre dois passos (ticks) da simulação
+const IntervaloTickPadrao = 50 * time.Millisecond
+
+// Opções de execução da simulação
+type ConfigSimulacao struct {
+	Intervalo      time.Duration         // intervalo entre os ticks
+	Semente        uint64                // semente dos geradores aleatórios dos inimigos
+	Deterministico bool                  // usa relógio simulado: mesma semente e roteiro geram a mesma partida
+	Gravacao       *Replay               // se definido, registra as ações do jogador aplicadas
+	Controles      <-chan ControleReplay // controles de reprodução (pausa, passo, velocidade)
+	Salvamento     string                // arquivo onde a tecla F5 salva o jogo
+	Campanha       *Campanha             // campanha em andamento (nil para um mapa avulso)
+}
+
+// Simulacao reúne os canais usados para conversar com a goroutine dona do jogo
+// Nenhum outro componente acessa o Jogo diretamente: eles enviam mensagens
+// por estes canais e recebem cópias imutáveis do estado (quadros)
+type Simulacao struct {
+	eventos    <-chan EventoTeclado    // ações do jogador vindas da fonte de entrada
+	previstos  bool                    // eventos vêm de um roteiro e são aplicados no seu Tempo
+	acoes      chan Comando            // resposta de cada inimigo na sua vez de agir
+	chanVida   chan int                // dano (negativo) ou cura (positivo) para o jogador
+	quadros    chan Jogo               // cópias do estado para o renderizador
+	inimigos   map[int]*canaisInimigo  // canais de cada goroutine de inimigo, por ID
+	projeteis  map[int]*canaisProjetil // canais de cada goroutine de projétil, por ID
+	ninhos     map[int]*canaisNinho    // canais de cada goroutine de ninho, por ID
+	alarmes    chan struct{}           // alarme dado por um inimigo que notou o personagem
+	fogo       *canaisFogo             // canais da goroutine do fogo (nil sem chamas)
+	explosoes  *canaisExplosoes        // canais da goroutine das explosões (nil sem explosões)
+	intervalo  time.Duration           // intervalo entre os ticks
+	relogio    Relogio                 // fonte do tempo do jogo
+	semente    uint64                  // semente dos geradores aleatórios
+	inicio     time.Time               // instante em que a simulação começou
+	proximo    *EventoTeclado          // próximo evento previsto ainda não aplicado
+	gravacao   *Replay                 // gravação da partida (nil se não está gravando)
+	controles  <-chan ControleReplay   // controles de reprodução (nil fora de replays)
+	pausado    bool                    // reprodução pausada: ticks só com "passo"
+	velocidade int                     // multiplicador de velocidade da reprodução
+	salvamento string                  // arquivo onde a tecla F5 salva o jogo
+	niveis     *rand.Rand              // sorteia algoritmo e semente dos níveis gerados
+	campanha   *Campanha               // campanha em andamento (nil para um mapa avulso)
+}
+
+// Canais de uma goroutine de inimigo
+type canaisInimigo struct {
+	vez       chan Jogo            // quadro enviado quando é a vez do inimigo agir
+	estimulos chan EstimuloInimigo // estímulos (ruídos e percepções) lidos pelo inimigo na sua vez
+	sair      chan struct{}        // fechado quando a entidade é destruída
+	proxima   time.Time            // instante da próxima ação do inimigo
+}
+
+// Canais de uma goroutine de projétil
+type canaisProjetil struct {
+	vez  chan Jogo     // quadro enviado a cada tick
+	sair chan struct{} // fechado quando o projétil para
+}
+
+// Canais de uma goroutine de ninho
+type canaisNinho struct {
+	vez    chan Jogo     // quadro enviado a cada tick
+	sinais chan struct{} // alarme repassado pela simulação (com buffer)
+	sair   chan struct{} // fechado quando o ninho é destruído
+}
+
+// Canais da goroutine do fogo
+type canaisFogo struct {
+	vez     chan Jogo     // quadro enviado quando é a vez do fogo agir
+	sair    chan struct{} // fechado quando a última chama se apaga
+	proxima time.Time     // instante da próxima vez do fogo
+}
+
+// Canais da goroutine das explosões
+type canaisExplosoes struct {
+	vez  chan Jogo     // quadro enviado a cada tick com explosões ativas
+	sair chan struct{} // fechado quando a última explosão termina
+}
+
+// Quantidade de ruídos que um inimigo acumula entre duas vezes de agir
+// Ruídos além desse limite são descartados; o canal tem uma vaga a mais,
+// para o que o inimigo percebe no quadro da sua vez
+const CapacidadeEstimulos = 8
+
+// Cria os canais da simulação
+// Sem modo determinístico, usa o relógio do sistema
+func simulacaoNova(config ConfigSimulacao, entrada FonteEntrada) *Simulacao {
+	var relogio Relogio = RelogioSistema{}
+	if config.Deterministico {
+		relogio = relogioSimuladoNovo()
+	}
+
+	_, previstos := entrada.(*FonteRoteiro)
+	return &Simulacao{
+		eventos:    entrada.Eventos(),
+		previstos:  previstos,
+		acoes:      make(chan Comando),
+		chanVida:   make(chan int),
+		quadros:    make(chan Jogo, 1),
+		inimigos:   make(map[int]*canaisInimigo),
+		projeteis:  make(map[int]*canaisProjetil),
+		ninhos:     make(map[int]*canaisNinho),
+		alarmes:    make(chan struct{}),
+		intervalo:  config.Intervalo,
+		relogio:    relogio,
+		semente:    config.Semente,
+		gravacao:   config.Gravacao,
+		controles:  config.Controles,
+		velocidade: 1,
+		salvamento: config.Salvamento,
+		niveis:     rand.New(rand.NewPCG(config.Semente, 0)),
+		campanha:   config.Campanha,
+	}
+}
+
+// Sorteia uma semente quando nenhuma foi informada
+func simulacaoSementeAleatoria() uint64 {
+	return rand.Uint64()
+}
+
+// ============================================================================
+// MÓDULO DO DONO DO ESTADO
+// ============================================================================
+
+// Executa o laço da simulação até o jogador sair do jogo
+// Esta goroutine é a única dona do Jogo: processa eventos de teclado e
+// alterações de vida na ordem em que chegam e, a cada tick, dá a vez aos
+// inimigos e atualiza bombas, explosões e condições de fim de jogo, mesmo sem
+// nenhuma tecla pressionada. Ao terminar, encerra as goroutines dos
+// elementos e fecha o canal de quadros para encerrar o renderizador
+func simulacaoExecutar(sim *Simulacao, jogo Jogo) {
+	defer close(sim.quadros)
+	defer simulacaoEncerrarTodos(sim)
+
+	sim.inicio = sim.relogio.Agora()
+	jogoAjustarTempo(&jogo, sim.inicio)
+	if sim.controles != nil {
+		simulacaoDescreverReplay(sim, &jogo)
+	}
+	simulacaoIniciarElementos(sim, &jogo)
+	simulacaoPublicar(sim, &jogo)
+
+	ticker := time.NewTicker(sim.intervalo)
+	defer ticker.Stop()
+
+	// Eventos previstos (roteiros) são lidos no início de cada tick
+	eventos := sim.eventos
+	if sim.previstos {
+		eventos = nil
+	}
+
+	for {
+		// Pausado, o relógio só avança com o controle "passo"
+		ticks := ticker.C
+		if sim.pausado {
+			ticks = nil
+		}
+
+		select {
+		case ev, ok := <-eventos:
+			// Processa a ação do jogador assim que ela chega
+			// (fonte de entrada encerrada equivale a sair do jogo)
+			jogo.Tempo = sim.relogio.Agora()
+			if !ok {
+				return
+			}
+			if continuar := simulacaoAplicarEvento(sim, &jogo, ev); !continuar {
+				return
+			}
+
+		case c := <-sim.controles:
+			if continuar := simulacaoControlar(sim, &jogo, c, ticker); !continuar {
+				return
+			}
+
+		case v := <-sim.chanVida:
+			jogo.Tempo = sim.relogio.Agora()
+			jogoAlterarVida(&jogo, v)
+
+		case <-ticks:
+			// Avança o estado do jogo em um passo
+			if continuar := simulacaoTick(sim, &jogo); !continuar {
+				return
+			}
+		}
+
+		simulacaoEncerrarRemovidos(sim, &jogo)
+		simulacaoPublicar(sim, &jogo)
+	}
+}
+
+// Executa um tick completo: eventos previstos, relógio, inimigos e passo
+// Retorna false se o jogador saiu do jogo
+func simulacaoTick(sim *Simulacao, jogo *Jogo) bool {
+	// Eventos previstos são aplicados antes de o relógio avançar
+	if sim.previstos {
+		if continuar := simulacaoAplicarPrevistos(sim, jogo); !continuar {
+			return false
+		}
+	}
+
+	sim.relogio.Avancar(sim.intervalo)
+	jogo.Tempo = sim.relogio.Agora()
+	jogoPiscarCuras(jogo)
+
+	simulacaoAgirInimigos(sim, jogo)
+	simulacaoAgirProjeteis(sim, jogo)
+	if !jogoEmIntroducao(jogo) {
+		simulacaoAgirNinhos(sim, jogo)
+		simulacaoIniciarGerados(sim, jogo)
+		simulacaoAgirFogo(sim, jogo)
+		explosoes := simulacaoPasso(jogo)
+		simulacaoAgirExplosoes(sim, jogo)
+		simulacaoAlertarInimigos(sim, jogo, explosoes)
+	}
+
+	// Vencido um nível da campanha, passa para o próximo
+	if sim.campanha != nil && jogo.Venceu && !jogo.Campanha.Concluida {
+		simulacaoProximoNivel(sim, jogo)
+	}
+	return true
+}
+
+// Avança a simulação em um passo: bombas, explosões e fim de jogo
+// Retorna a posição das bombas que explodiram neste passo
+func simulacaoPasso(jogo *Jogo) []Ponto {
+	// Recarrega a mochila e atualiza bombas e explosões
+	jogoRecarregarBombas(jogo)
+	explosoes := jogoAtualizarBombas(jogo)
+	jogoAtualizarExplosoes(jogo)
+
+	// Verifica condições de fim de jogo (atualiza StatusMsg se necessário)
+	jogoVerificarDerrota(jogo)
+	jogoVerificarVitoria(jogo)
+	return explosoes
+}
+
+// Aplica os eventos previstos cujo Tempo já foi atingido pelo jogo
+// Lê a fonte até encontrar um evento futuro, que fica guardado para os
+// próximos ticks. Retorna false se o jogador saiu ou o roteiro terminou
+func simulacaoAplicarPrevistos(sim *Simulacao, jogo *Jogo) bool {
+	decorrido := jogo.Tempo.Sub(sim.inicio)
+	for {
+		if sim.proximo == nil {
+			ev, ok := <-sim.eventos
+			if !ok {
+				return false // Fim do roteiro
+			}
+			sim.proximo = &ev
+		}
+
+		if sim.proximo.Tempo > decorrido {
+			return true // Evento futuro: aguarda os próximos ticks
+		}
+
+		ev := *sim.proximo
+		sim.proximo = nil
+		if continuar := simulacaoAplicarEvento(sim, jogo, ev); !continuar {
+			return false
+		}
+	}
+}
+
+// Executa a ação do jogador e a registra na gravação, se houver
+// Salvar o jogo não altera a partida e por isso não é gravado.
+// Retorna false se o jogador saiu do jogo
+func simulacaoAplicarEvento(sim *Simulacao, jogo *Jogo, ev EventoTeclado) bool {
+	if ev.Tipo == "salvar" {
+		simulacaoSalvar(sim, jogo)
+		return true
+	}
+
+	if sim.gravacao != nil {
+		replayRegistrar(sim.gravacao, jogo.Tempo.Sub(sim.inicio), ev)
+	}
+	if ev.Tipo == "novo" {
+		simulacaoNovoNivel(sim, jogo)
+		return true
+	}
+	return personagemExecutarAcao(ev, jogo)
+}
+
+// Troca o mapa atual por um nível gerado com os parâmetros padrão
+// O algoritmo e a semente vêm do gerador de níveis da simulação, derivado da
+// semente da partida, para que replays gerem os mesmos níveis. Os inimigos
+// antigos são encerrados por simulacaoEncerrarRemovidos
+func simulacaoNovoNivel(sim *Simulacao, jogo *Jogo) {
+	if sim.campanha != nil {
+		jogo.StatusMsg = "Novo nível aleatório indisponível durante a campanha"
+		return
+	}
+
+	config := geradorConfigPadrao()
+	config.Algoritmo = AlgoritmosGerador[sim.niveis.IntN(len(AlgoritmosGerador))]
+	config.Semente = sim.niveis.Uint64()
+
+	novo, err := jogoNovoNivelAleatorio(jogo, config)
+	if err != nil {
+		jogo.StatusMsg = fmt.Sprintf("Erro ao gerar nível: %v", err)
+		return
+	}
+
+	*jogo = novo
+	for _, ent := range jogo.Entidades[1:] {
+		simulacaoIniciarInimigo(sim, jogo, ent)
+	}
+}
+
+// Salva o jogo no arquivo configurado e informa o resultado na barra de status
+func simulacaoSalvar(sim *Simulacao, jogo *Jogo) {
+	if sim.salvamento == "" {
+		jogo.StatusMsg = "Salvamento desativado"
+		return
+	}
+	if err := jogoSalvar(jogo, sim.salvamento); err != nil {
+		jogo.StatusMsg = fmt.Sprintf("Erro ao salvar: %v", err)
+		return
+	}
+	jogo.StatusMsg = "Jogo salvo em " + sim.salvamento
+}
+
+// Carrega o próximo nível da campanha, mantendo vida e pontuação
+// Depois do último nível, a campanha é marcada como concluída e o jogo
+// termina com a tela de campanha completa
+func simulacaoProximoNivel(sim *Simulacao, jogo *Jogo) {
+	jogo.Pontos += PontosNivel
+
+	if jogo.Campanha.Nivel >= len(sim.campanha.Mapas) {
+		jogo.Campanha.Concluida = true
+		jogo.StatusMsg = "CAMPANHA CONCLUIDA! " + jogo.Campanha.Nome
+		return
+	}
+
+	novo, err := jogoCarregarNivel(jogo, sim.campanha, jogo.Campanha.Nivel+1)
+	if err != nil {
+		jogo.Venceu = false
+		jogo.StatusMsg = fmt.Sprintf("Erro ao carregar o nível %d: %v", jogo.Campanha.Nivel+1, err)
+		return
+	}
+
+	*jogo = novo
+	for _, ent := range jogo.Entidades[1:] {
+		simulacaoIniciarInimigo(sim, jogo, ent)
+	}
+}
+
+// ============================================================================
+// MÓDULO DE CONTROLE DA REPRODUÇÃO
+// ============================================================================
+
+// Aplica um controle de reprodução: pausa, passo a passo, velocidade ou saída
+// A velocidade muda apenas o intervalo real entre os ticks; o tempo de jogo
+// de cada tick continua o mesmo, mantendo a reprodução fiel à gravação
+func simulacaoControlar(sim *Simulacao, jogo *Jogo, c ControleReplay, ticker *time.Ticker) bool {
+	switch c.Tipo {
+	case "sair":
+		return false
+	case "pausar":
+		sim.pausado = !sim.pausado
+	case "passo":
+		if sim.pausado {
+			if continuar := simulacaoTick(sim, jogo); !continuar {
+				return false
+			}
+		}
+	case "velocidade":
+		if c.Velocidade > 0 {
+			sim.velocidade = c.Velocidade
+			ticker.Reset(sim.intervalo / time.Duration(sim.velocidade))
+		}
+	}
+
+	simulacaoDescreverReplay(sim, jogo)
+	return true
+}
+
+// Atualiza a descrição da reprodução mostrada na barra de status
+func simulacaoDescreverReplay(sim *Simulacao, jogo *Jogo) {
+	situacao := fmt.Sprintf("REPLAY %dx", sim.velocidade)
+	if sim.pausado {
+		situacao += " (pausado)"
+	}
+	jogo.InfoReplay = situacao + " - ESPAÇO pausa, N avança um passo, 1/2/4 velocidade, ESC sai"
+}
+
+// ============================================================================
+// MÓDULO DE GERENCIAMENTO DOS INIMIGOS
+// ============================================================================
+
+// Inicia as goroutines dos elementos autônomos (inimigos)
+func simulacaoIniciarElementos(sim *Simulacao, jogo *Jogo) {
+	for _, ent := range jogo.Entidades[1:] { // O personagem é o índice 0
+		simulacaoIniciarInimigo(sim, jogo, ent)
+	}
+}
+
+// Inicia a goroutine do inimigo informado
+func simulacaoIniciarInimigo(sim *Simulacao, jogo *Jogo, ent Entidade) {
+	// Os inimigos só começam a agir depois da apresentação do nível
+	inicio := jogo.Tempo
+	if jogoEmIntroducao(jogo) {
+		inicio = jogo.IntroducaoAte
+	}
+
+	canais := &canaisInimigo{
+		vez:       make(chan Jogo),
+		estimulos: make(chan EstimuloInimigo, CapacidadeEstimulos+1),
+		sair:      make(chan struct{}),
+		proxima:   inicio.Add(arquetipoIntervalo(jogo, ent)),
+	}
+	sim.inimigos[ent.ID] = canais
+	go inimigoExecutar(ent.ID, sim.semente, canais.vez, canais.estimulos, canais.sair, sim.acoes, sim.chanVida, sim.alarmes)
+}
+
+// Avisa os inimigos que ouviram as explosões informadas
+// O aviso é um estímulo de ruído enviado sem bloquear; o inimigo o lê na
+// sua próxima vez de agir, o que mantém a partida reproduzível
+func simulacaoAlertarInimigos(sim *Simulacao, jogo *Jogo, explosoes []Ponto) {
+	for _, p := range explosoes {
+		for _, ent := range jogo.Entidades[1:] {
+			canais, ok := sim.inimigos[ent.ID]
+			if !ok || inimigoDetectaPersonagem(ent.X, ent.Y, p.X, p.Y) > AlcanceRuido {
+				continue
+			}
+			if len(canais.estimulos) >= CapacidadeEstimulos {
+				continue // Inimigo com muitos ruídos pendentes: descarta
+			}
+			canais.estimulos <- EstimuloInimigo{Tipo: EstimuloRuido, Ponto: p}
+		}
+	}
+}
+
+// Dá a vez a cada inimigo cuja próxima ação já chegou, na ordem de
+// jogo.Entidades, e aplica a resposta de cada um antes de chamar o próximo
+// Os inimigos decidem em suas próprias goroutines, mas a ordem das ações
+// não depende do escalonamento, o que mantém a partida reproduzível
+func simulacaoAgirInimigos(sim *Simulacao, jogo *Jogo) {
+	var quadro *Jogo // Cópia feita apenas se algum inimigo agir neste tick
+
+	inimigos := append([]Entidade(nil), jogo.Entidades[1:]...)
+
+	for _, ent := range inimigos {
+		canais, ok := sim.inimigos[ent.ID]
+		if !ok || jogo.Tempo.Before(canais.proxima) {
+			continue
+		}
+		canais.proxima = canais.proxima.Add(arquetipoIntervalo(jogo, ent))
+
+		if quadro == nil {
+			q := jogoCopiar(jogo)
+			quadro = &q
+		}
+		simulacaoEnviarPercepcoes(canais, quadro, ent.ID)
+		canais.vez <- *quadro
+		simulacaoAguardarAcao(sim, jogo)
+	}
+}
+
+// Envia ao inimigo, como estímulos, o que ele percebe no quadro da sua vez
+// (personagem à vista, perdido de vista, fim da procura ou volta ao posto)
+func simulacaoEnviarPercepcoes(canais *canaisInimigo, quadro *Jogo, id int) {
+	idx := jogoBuscarEntidade(quadro, id)
+	if idx <= 0 {
+		return
+	}
+	ent := quadro.Entidades[idx]
+	visto, _ := inimigoVePersonagem(quadro, idx)
+	for _, e := range inimigoPerceber(quadro, &ent.Mente, Ponto{ent.X, ent.Y}, visto) {
+		canais.estimulos <- e
+	}
+}
+
+// Dá a vez a cada projétil em voo, na ordem de jogo.Projeteis
+// Os projéteis andam uma célula por tick. A goroutine de um projétil recém
+// disparado é iniciada na sua primeira vez
+func simulacaoAgirProjeteis(sim *Simulacao, jogo *Jogo) {
+	if len(jogo.Projeteis) == 0 {
+		return
+	}
+
+	quadro := jogoCopiar(jogo)
+	for _, p := range quadro.Projeteis {
+		canais, ok := sim.projeteis[p.ID]
+		if !ok {
+			canais = &canaisProjetil{vez: make(chan Jogo), sair: make(chan struct{})}
+			sim.projeteis[p.ID] = canais
+			go projetilExecutar(p.ID, canais.vez, canais.sair, sim.acoes, sim.chanVida)
+		}
+		canais.vez <- quadro
+		simulacaoAguardarAcao(sim, jogo)
+	}
+}
+
+// Dá a vez a cada ninho, na ordem de jogo.Ninhos
+// A goroutine de cada ninho é iniciada na sua primeira vez
+func simulacaoAgirNinhos(sim *Simulacao, jogo *Jogo) {
+	if len(jogo.Ninhos) == 0 {
+		return
+	}
+
+	quadro := jogoCopiar(jogo)
+	for _, n := range quadro.Ninhos {
+		canais, ok := sim.ninhos[n.ID]
+		if !ok {
+			canais = &canaisNinho{vez: make(chan Jogo), sinais: make(chan struct{}, 1), sair: make(chan struct{})}
+			sim.ninhos[n.ID] = canais
+			go ninhoExecutar(n.ID, canais.vez, canais.sinais, canais.sair, sim.acoes)
+		}
+		canais.vez <- quadro
+		simulacaoAguardarAcao(sim, jogo)
+	}
+}
+
+// Inicia as goroutines dos inimigos gerados neste tick por ninhos ou
+// invocados pelo chefe; eles começam a agir depois de um intervalo
+func simulacaoIniciarGerados(sim *Simulacao, jogo *Jogo) {
+	for _, ent := range jogo.Entidades[1:] {
+		if _, ok := sim.inimigos[ent.ID]; !ok {
+			simulacaoIniciarInimigo(sim, jogo, ent)
+		}
+	}
+}
+
+// Dá a vez ao fogo a cada IntervaloFogo enquanto houver chamas
+// A goroutine do fogo é iniciada quando a primeira chama aparece e age logo
+// no tick seguinte
+func simulacaoAgirFogo(sim *Simulacao, jogo *Jogo) {
+	if len(jogo.Chamas) == 0 {
+		return
+	}
+	if sim.fogo == nil {
+		sim.fogo = &canaisFogo{vez: make(chan Jogo), sair: make(chan struct{}), proxima: jogo.Tempo}
+		go fogoExecutar(sim.fogo.vez, sim.fogo.sair, sim.acoes, sim.chanVida)
+	}
+	if jogo.Tempo.Before(sim.fogo.proxima) {
+		return
+	}
+	sim.fogo.proxima = sim.fogo.proxima.Add(IntervaloFogo)
+
+	sim.fogo.vez <- jogoCopiar(jogo)
+	simulacaoAguardarAcao(sim, jogo)
+}
+
+// Dá a vez às explosões a cada tick enquanto houver alguma ativa
+// A goroutine das explosões é iniciada quando a primeira explosão aparece
+func simulacaoAgirExplosoes(sim *Simulacao, jogo *Jogo) {
+	if len(jogo.Explosoes) == 0 {
+		return
+	}
+	if sim.explosoes == nil {
+		sim.explosoes = &canaisExplosoes{vez: make(chan Jogo), sair: make(chan struct{})}
+		go explosaoExecutar(sim.explosoes.vez, sim.explosoes.sair, sim.acoes, sim.chanVida)
+	}
+
+	sim.explosoes.vez <- jogoCopiar(jogo)
+	simulacaoAguardarAcao(sim, jogo)
+}
+
+// Repassa o alarme de um inimigo a todos os ninhos
+// O sinal fica no buffer do ninho até a sua próxima vez; alarmes repetidos
+// antes disso contam como um só
+func simulacaoAlarmarNinhos(sim *Simulacao) {
+	for _, canais := range sim.ninhos {
+		select {
+		case canais.sinais <- struct{}{}:
+		default: // O ninho já tem um alarme pendente
+		}
+	}
+}
+
+// Aguarda a resposta do inimigo, projétil, ninho, fogo ou explosão que está agindo
+// Ele pode enviar dano pelo canal chanVida ou um alarme antes de responder
+func simulacaoAguardarAcao(sim *Simulacao, jogo *Jogo) {
+	for {
+		select {
+		case v := <-sim.chanVida:
+			jogoAlterarVida(jogo, v)
+		case <-sim.alarmes:
+			simulacaoAlarmarNinhos(sim)
+		case cmd := <-sim.acoes:
+			cmd.executar(jogo)
+			return
+		}
+	}
+}
+
+// Laço das goroutines que agem na sua vez (inimigos, projéteis, ninhos e fogo)
+// Responde a cada quadro com o comando de agir, até o canal sair ser fechado
+func simulacaoAgirNaVez(vez <-chan Jogo, sair <-chan struct{}, acoes chan<- Comando, agir func(quadro *Jogo) Comando) {
+	for {
+		select {
+		case <-sair:
+			return
+		case quadro := <-vez:
+			acoes <- agir(&quadro)
+		}
+	}
+}
+
+// Encerra as goroutines dos inimigos cujas entidades foram destruídas, dos
+// projéteis que pararam, dos ninhos destruídos, do fogo apagado e das
+// explosões terminadas
+func simulacaoEncerrarRemovidos(sim *Simulacao, jogo *Jogo) {
+	for id, canais := range sim.inimigos {
+		if jogoBuscarEntidade(jogo, id) < 0 {
+			close(canais.sair)
+			delete(sim.inimigos, id)
+		}
+	}
+	for id, canais := range sim.projeteis {
+		if jogoBuscarProjetil(jogo, id) < 0 {
+			close(canais.sair)
+			delete(sim.projeteis, id)
+		}
+	}
+	for id, canais := range sim.ninhos {
+		if jogoBuscarNinho(jogo, id) < 0 {
+			close(canais.sair)
+			delete(sim.ninhos, id)
+		}
+	}
+	if sim.fogo != nil && len(jogo.Chamas) == 0 {
+		close(sim.fogo.sair)
+		sim.fogo = nil
+	}
+	if sim.explosoes != nil && len(jogo.Explosoes) == 0 {
+		close(sim.explosoes.sair)
+		sim.explosoes = nil
+	}
+}
+
+// Encerra as goroutines de todos os elementos ainda ativos, como se tivessem
+// sido removidos de um jogo vazio
+func simulacaoEncerrarTodos(sim *Simulacao) {
+	simulacaoEncerrarRemovidos(sim, &Jogo{})
+}
+
+// ============================================================================
+// MÓDULO DE DISTRIBUIÇÃO DE QUADROS
+// ============================================================================
+
+// Envia uma cópia do estado atual ao renderizador
+// A cópia nunca é modificada depois de enviada
+func simulacaoPublicar(sim *Simulacao, jogo *Jogo) {
+	simulacaoEnviarQuadro(sim.quadros, jogoCopiar(jogo))
+}
+
+// Substitui o quadro pendente no canal (com buffer 1) pelo mais recente
+// Nunca bloqueia, pois a simulação é a única que envia neste canal
+func simulacaoEnviarQuadro(ch chan Jogo, quadro Jogo) {
+	select {
+	case <-ch: // Descarta o quadro antigo ainda não consumido
+	default:
+	}
+	ch <- quadro
+}
@@ -0,0 +1,99 @@
+// simulacao_test.go - Testes da goroutine dona do estado do jogo
+// Rode com "go test -race" para verificar também a ausência de condições de corrida
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Intervalo entre os ticks nos testes (o relógio simulado avança isso por tick)
+const IntervaloTickTeste = 5 * time.Millisecond
+
+// Carrega o mapa de teste informado, sem campanha nem salvamento
+func testeCarregarMapa(t *testing.T, nome string) Jogo {
+	t.Helper()
+	jogo := jogoNovo()
+	if err := jogoCarregarMapa(nome, &jogo); err != nil {
+		t.Fatal(err)
+	}
+	return jogo
+}
+
+// Executa a simulação até a fonte de entrada terminar e retorna o último quadro
+func testeExecutarSimulacao(jogo Jogo, semente uint64, entrada FonteEntrada) Jogo {
+	config := ConfigSimulacao{Intervalo: IntervaloTickTeste, Semente: semente, Deterministico: true}
+	return testeExecutarConfig(jogo, config, entrada)
+}
+
+// Executa a simulação com a configuração informada até a fonte de entrada
+// terminar e retorna o último quadro
+func testeExecutarConfig(jogo Jogo, config ConfigSimulacao, entrada FonteEntrada) Jogo {
+	sim := simulacaoNova(config, entrada)
+	go simulacaoExecutar(sim, jogo)
+
+	var ultimo Jogo
+	for quadro := range sim.quadros {
+		ultimo = quadro
+	}
+	return ultimo
+}
+
+// Duas simulações com a mesma semente e o mesmo roteiro, executadas ao mesmo
+// tempo, devem terminar no mesmo quadro. O mapa tem inimigos que perseguem,
+// um atirador, um ninho, uma cura e vegetação para o fogo da bomba
+func TestSimulacaoRoteiroDeterministico(t *testing.T) {
+	roteiro, err := entradaLerRoteiro("testdata/simulacao_roteiro.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	jogo := testeCarregarMapa(t, "testdata/simulacao.txt")
+
+	quadros := make(chan Jogo)
+	for range 2 {
+		go func() {
+			quadros <- testeExecutarSimulacao(jogoCopiar(&jogo), 42, fonteRoteiroNova(roteiro))
+		}()
+	}
+	a, b := <-quadros, <-quadros
+
+	duracao := roteiro[len(roteiro)-1].Tempo
+	if decorrido := a.Tempo.Sub(relogioSimuladoNovo().Agora()); decorrido < duracao {
+		t.Errorf("simulação parou em %v, antes do fim do roteiro (%v)", decorrido, duracao)
+	}
+	if len(a.Bombas) != 0 {
+		t.Errorf("bomba colocada no início não explodiu: %+v", a.Bombas)
+	}
+	// Compara também as cores, como a da cura piscante
+	telaA, telaB := testeDesenharMemoria(a), testeDesenharMemoria(b)
+	if !reflect.DeepEqual(telaA.exibido, telaB.exibido) {
+		t.Errorf("mesma semente e roteiro geraram quadros diferentes:\n%s\n---\n%s", telaA.Texto(), telaB.Texto())
+	}
+}
+
+// Ao fim da partida, as goroutines dos inimigos, projéteis, ninhos, fogo e
+// explosões que ainda agiam são encerradas junto com a simulação
+func TestSimulacaoEncerraElementos(t *testing.T) {
+	roteiro, err := entradaLerRoteiro("testdata/simulacao_roteiro.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	jogo := testeCarregarMapa(t, "testdata/simulacao.txt")
+	config := ConfigSimulacao{Intervalo: IntervaloTickTeste, Semente: 42, Deterministico: true}
+	sim := simulacaoNova(config, fonteRoteiroNova(roteiro[:5]))
+	go simulacaoExecutar(sim, jogo)
+
+	var ultimo Jogo
+	for quadro := range sim.quadros {
+		ultimo = quadro
+	}
+	if len(ultimo.Ninhos) == 0 || len(ultimo.Entidades) < 2 {
+		t.Fatalf("a partida terminou sem inimigos ou ninhos para encerrar: %d entidades, %d ninhos",
+			len(ultimo.Entidades), len(ultimo.Ninhos))
+	}
+	if len(sim.inimigos) != 0 || len(sim.projeteis) != 0 || len(sim.ninhos) != 0 || sim.fogo != nil || sim.explosoes != nil {
+		t.Errorf("goroutines ainda ativas: %d inimigos, %d projéteis, %d ninhos, fogo %v, explosões %v",
+			len(sim.inimigos), len(sim.projeteis), len(sim.ninhos), sim.fogo != nil, sim.explosoes != nil)
+	}
+}
@@ -0,0 +1,280 @@
+// validacao.go - Verificação dos arquivos de mapa antes do jogo começar
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErroMapa é um problema encontrado em um arquivo de mapa
+// Linha e Coluna contam a partir de 1 no arquivo; zero indica que o
+// problema é do mapa como um todo
+type ErroMapa struct {
+	Linha, Coluna int
+	Msg           string
+}
+
+func (e ErroMapa) Error() string {
+	switch {
+	case e.Linha == 0:
+		return e.Msg
+	case e.Coluna == 0:
+		return fmt.Sprintf("linha %d: %s", e.Linha, e.Msg)
+	}
+	return fmt.Sprintf("linha %d, coluna %d: %s", e.Linha, e.Coluna, e.Msg)
+}
+
+// ErrosMapa reúne todos os problemas de um mapa inválido
+type ErrosMapa struct {
+	Nome  string // arquivo do mapa
+	Erros []ErroMapa
+}
+
+func (e *ErrosMapa) Error() string {
+	linhas := make([]string, len(e.Erros))
+	for i, erro := range e.Erros {
+		linhas[i] = fmt.Sprintf("%s: %v", e.Nome, erro)
+	}
+	return strings.Join(linhas, "\n")
+}
+
+// ============================================================================
+// MÓDULO DE VALIDAÇÃO DE MAPAS
+// ============================================================================
+
+// Verifica se o mapa pode ser jogado
+// Retorna nil ou um *ErrosMapa com todos os problemas encontrados: personagem
+// ausente ou repetido, caracteres fora da legenda, linhas de larguras
+// diferentes, bordas sem parede, inimigos, ninhos ou curas que o personagem não
+// consegue alcançar, rotas de patrulha com pontos inválidos e inimigos grandes
+// (como o chefe) sem espaço livre no mapa
+func mapaValidar(nome string, arq *ArquivoMapa) error {
+	v := &ErrosMapa{Nome: nome}
+	grade := make([][]string, len(arq.Linhas)) // tipo de cada célula
+	var personagens [][2]int                   // posições (x, y) do personagem
+
+	for y, linha := range arq.Linhas {
+		for x, ch := range []rune(linha) {
+			tipo, ok := arq.Legenda[ch]
+			if !ok {
+				validacaoErro(v, arq, x, y, fmt.Sprintf("caractere desconhecido %q", ch))
+				tipo = "" // Já reportado; não é verificado como borda
+			}
+			if tipo == TipoPersonagem {
+				personagens = append(personagens, [2]int{x, y})
+			}
+			grade[y] = append(grade[y], tipo)
+		}
+	}
+
+	if len(grade) == 0 {
+		v.Erros = append(v.Erros, ErroMapa{Msg: "mapa vazio"})
+		return v
+	}
+
+	validacaoLarguras(v, arq, grade)
+	validacaoBordas(v, arq, grade)
+
+	switch {
+	case len(personagens) == 0:
+		v.Erros = append(v.Erros, ErroMapa{Msg: "o mapa não tem personagem (" + string(Personagem.simbolo) + ")"})
+	case len(personagens) > 1:
+		for _, p := range personagens[1:] {
+			validacaoErro(v, arq, p[0], p[1], "personagem repetido")
+		}
+	}
+	if len(personagens) > 0 {
+		validacaoAlcance(v, arq, grade, personagens[0][0], personagens[0][1])
+	}
+
+	validacaoRotas(v, arq, grade)
+	validacaoTamanhos(v, arq, grade)
+
+	if arq.Cabecalho.Vitoria == VitoriaCuras && !validacaoTemTipo(grade, TipoCura) {
+		v.Erros = append(v.Erros, ErroMapa{Msg: "a vitória por curas exige ao menos uma cura no mapa"})
+	}
+
+	if len(v.Erros) > 0 {
+		// Ordena pela posição no arquivo; erros do mapa todo ficam no fim
+		sort.SliceStable(v.Erros, func(i, j int) bool {
+			a, b := v.Erros[i], v.Erros[j]
+			if (a.Linha == 0) != (b.Linha == 0) {
+				return b.Linha == 0
+			}
+			if a.Linha != b.Linha {
+				return a.Linha < b.Linha
+			}
+			return a.Coluna < b.Coluna
+		})
+		return v
+	}
+	return nil
+}
+
+// Registra um erro na célula (x, y) da grade
+func validacaoErro(v *ErrosMapa, arq *ArquivoMapa, x, y int, msg string) {
+	v.Erros = append(v.Erros, ErroMapa{Linha: arq.PrimeiraLinha + y, Coluna: x + 1, Msg: msg})
+}
+
+// Verifica se todas as linhas têm a largura da primeira
+func validacaoLarguras(v *ErrosMapa, arq *ArquivoMapa, grade [][]string) {
+	largura := len(grade[0])
+	for y, linha := range grade {
+		if len(linha) != largura {
+			msg := fmt.Sprintf("linha com largura %d, esperada %d (largura da primeira linha)", len(linha), largura)
+			v.Erros = append(v.Erros, ErroMapa{Linha: arq.PrimeiraLinha + y, Msg: msg})
+		}
+	}
+}
+
+// Verifica se as bordas do mapa são fechadas por paredes
+func validacaoBordas(v *ErrosMapa, arq *ArquivoMapa, grade [][]string) {
+	for y, linha := range grade {
+		for x, tipo := range linha {
+			borda := y == 0 || y == len(grade)-1 || x == 0 || x == len(linha)-1
+			if borda && tipo != TipoParede && tipo != "" {
+				validacaoErro(v, arq, x, y, "borda aberta (esperada parede)")
+			}
+		}
+	}
+}
+
+// Verifica se o personagem consegue chegar a todos os inimigos, ninhos e curas
+// Percorre em largura as células não tangíveis a partir do personagem,
+// andando nas quatro direções como o personagem anda
+func validacaoAlcance(v *ErrosMapa, arq *ArquivoMapa, grade [][]string, px, py int) {
+	alcancado := make([][]bool, len(grade))
+	for y := range grade {
+		alcancado[y] = make([]bool, len(grade[y]))
+	}
+
+	fila := [][2]int{{px, py}}
+	alcancado[py][px] = true
+	for len(fila) > 0 {
+		x, y := fila[0][0], fila[0][1]
+		fila = fila[1:]
+
+		for _, d := range [][2]int{{0, -1}, {-1, 0}, {0, 1}, {1, 0}} {
+			nx, ny := x+d[0], y+d[1]
+			if ny < 0 || ny >= len(grade) || nx < 0 || nx >= len(grade[ny]) || alcancado[ny][nx] {
+				continue
+			}
+			tipo := grade[ny][nx]
+			if elem, _ := mapaElementoDoTipo(tipo); elem.tangivel && !validacaoAlvo(tipo) && tipo != TipoFragil {
+				continue // Inimigos e ninhos bloqueiam, mas podem ser alcançados; paredes frágeis, derrubadas
+			}
+			alcancado[ny][nx] = true
+			fila = append(fila, [2]int{nx, ny})
+		}
+	}
+
+	for y, linha := range grade {
+		for x, tipo := range linha {
+			if alcancado[y][x] {
+				continue
+			}
+			switch {
+			case arquetipoEhInimigo(tipo):
+				validacaoErro(v, arq, x, y, "inimigo inalcançável pelo personagem")
+			case tipo == TipoNinho:
+				validacaoErro(v, arq, x, y, "ninho inalcançável pelo personagem")
+			case tipo == TipoCura:
+				validacaoErro(v, arq, x, y, "cura inalcançável pelo personagem")
+			}
+		}
+	}
+}
+
+// Indica se o tipo é algo que o personagem precisa alcançar para vencer
+// (inimigos e ninhos), mesmo que bloqueie a passagem
+func validacaoAlvo(tipo string) bool {
+	return arquetipoEhInimigo(tipo) || tipo == TipoNinho
+}
+
+// Verifica as rotas de patrulha
+// Cada rota deve começar na posição de um inimigo que ainda não tenha rota e
+// passar apenas por células dentro da grade que não bloqueiam a passagem
+func validacaoRotas(v *ErrosMapa, arq *ArquivoMapa, grade [][]string) {
+	comRota := make(map[Ponto]bool)
+	for _, rota := range arq.Rotas {
+		erro := func(msg string) {
+			v.Erros = append(v.Erros, ErroMapa{Linha: rota.Linha, Msg: "rota: " + msg})
+		}
+
+		inicio := rota.Pontos[0]
+		switch {
+		case !arquetipoEhInimigo(validacaoTipoEm(grade, inicio)):
+			erro(fmt.Sprintf("o primeiro ponto (%d, %d) deve ser a posição de um inimigo", inicio.X, inicio.Y))
+		case comRota[inicio]:
+			erro(fmt.Sprintf("o inimigo em (%d, %d) já tem uma rota", inicio.X, inicio.Y))
+		}
+		comRota[inicio] = true
+
+		for _, p := range rota.Pontos[1:] {
+			tipo := validacaoTipoEm(grade, p)
+			if tipo == "" {
+				erro(fmt.Sprintf("ponto (%d, %d) fora do mapa", p.X, p.Y))
+				continue
+			}
+			if elem, _ := mapaElementoDoTipo(tipo); elem.tangivel && !arquetipoEhInimigo(tipo) && tipo != TipoPersonagem {
+				erro(fmt.Sprintf("ponto (%d, %d) bloqueado (%s)", p.X, p.Y, tipo))
+			}
+		}
+	}
+}
+
+// Verifica se os inimigos grandes (como o chefe) cabem no mapa
+// O símbolo marca o canto superior esquerdo; as demais células do quadrado
+// devem estar dentro da grade e livres
+func validacaoTamanhos(v *ErrosMapa, arq *ArquivoMapa, grade [][]string) {
+	for y, linha := range grade {
+		for x, tipo := range linha {
+			arquetipo, ok := arquetipoBuscar(tipo)
+			if !ok || arquetipo.Tamanho <= 1 {
+				continue
+			}
+			if !validacaoQuadradoLivre(grade, x, y, arquetipo.Tamanho) {
+				validacaoErro(v, arq, x, y, fmt.Sprintf("%s sem espaço: precisa de %dx%d células livres a partir desta posição",
+					tipo, arquetipo.Tamanho, arquetipo.Tamanho))
+			}
+		}
+	}
+}
+
+// Indica se o quadrado de lado t a partir de (x, y), exceto a própria
+// célula (x, y), está dentro da grade e não bloqueia a passagem
+func validacaoQuadradoLivre(grade [][]string, x, y, t int) bool {
+	for cy := y; cy < y+t; cy++ {
+		for cx := x; cx < x+t; cx++ {
+			if cx == x && cy == y {
+				continue
+			}
+			tipo := validacaoTipoEm(grade, Ponto{cx, cy})
+			if elem, ok := mapaElementoDoTipo(tipo); !ok || elem.tangivel {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Retorna o tipo da célula p da grade ou "" se ela está fora da grade
+func validacaoTipoEm(grade [][]string, p Ponto) string {
+	if p.Y < 0 || p.Y >= len(grade) || p.X < 0 || p.X >= len(grade[p.Y]) {
+		return ""
+	}
+	return grade[p.Y][p.X]
+}
+
+// Indica se algum elemento do tipo informado aparece na grade
+func validacaoTemTipo(grade [][]string, tipo string) bool {
+	for _, linha := range grade {
+		for _, t := range linha {
+			if t == tipo {
+				return true
+			}
+		}
+	}
+	return false
+}
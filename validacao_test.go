@@ -0,0 +1,60 @@
+// validacao_test.go - Testes da validação dos mapas
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// Cada mapa quebrado é recusado com os problemas na linha e coluna do
+// arquivo, ordenados pela posição, e os problemas do mapa todo no fim
+func TestMapaValidar(t *testing.T) {
+	casos := []struct {
+		nome  string
+		texto string
+		erros []ErroMapa // Msg guarda só um trecho da mensagem esperada
+	}{
+		{"caractere fora da legenda", "▤▤▤▤\n▤☺X▤\n▤▤▤▤",
+			[]ErroMapa{{2, 3, "caractere desconhecido 'X'"}}},
+		{"borda aberta", "▤▤▤▤\n ☺ ▤\n▤▤▤▤",
+			[]ErroMapa{{2, 1, "borda aberta"}}},
+		{"largura diferente", "▤▤▤▤\n▤☺▤\n▤▤▤▤",
+			[]ErroMapa{{2, 0, "largura 3, esperada 4"}}},
+		{"sem personagem", "▤▤▤\n▤ ▤\n▤▤▤",
+			[]ErroMapa{{0, 0, "não tem personagem"}}},
+		{"personagem repetido depois do cabeçalho", "[cabecalho]\nnome: Dois\n[mapa]\n▤▤▤▤\n▤☺☺▤\n▤▤▤▤",
+			[]ErroMapa{{5, 3, "personagem repetido"}}},
+		{"inimigo inalcançável", "▤▤▤▤▤\n▤☺▤☠▤\n▤▤▤▤▤",
+			[]ErroMapa{{2, 4, "inimigo inalcançável"}}},
+		{"rota que não começa em um inimigo", "[rotas]\nciclo: 1,1 3,1\n[mapa]\n▤▤▤▤▤\n▤☺☠ ▤\n▤▤▤▤▤",
+			[]ErroMapa{{2, 0, "deve ser a posição de um inimigo"}}},
+		{"chefe sem espaço", "▤▤▤▤\n▤☺♛▤\n▤  ▤\n▤▤▤▤",
+			[]ErroMapa{{2, 3, "chefe sem espaço"}}},
+		{"vários problemas", "▤▤▤▤\n▤ X▤\n▤ ▤\n▤▤▤▤",
+			[]ErroMapa{{2, 3, "caractere desconhecido"}, {3, 0, "largura 3"}, {0, 0, "não tem personagem"}}},
+	}
+
+	for _, caso := range casos {
+		arq, err := mapaInterpretar("teste.txt", strings.NewReader(caso.texto))
+		if err != nil {
+			t.Fatalf("%s: %v", caso.nome, err)
+		}
+
+		var v *ErrosMapa
+		if err := mapaValidar("teste.txt", arq); !errors.As(err, &v) {
+			t.Errorf("%s: mapa aceito (erro %v)", caso.nome, err)
+			continue
+		}
+		if len(v.Erros) != len(caso.erros) {
+			t.Errorf("%s: erros %v, esperados %v", caso.nome, v.Erros, caso.erros)
+			continue
+		}
+		for i, esperado := range caso.erros {
+			erro := v.Erros[i]
+			if erro.Linha != esperado.Linha || erro.Coluna != esperado.Coluna || !strings.Contains(erro.Msg, esperado.Msg) {
+				t.Errorf("%s: erro %d = %+v, esperado %+v", caso.nome, i, erro, esperado)
+			}
+		}
+	}
+}